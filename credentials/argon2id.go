@@ -0,0 +1,171 @@
+package credentials
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idMaxMemory     = 1024 * 1024
+	argon2idMaxIterations = 64
+	argon2idMinSaltLength = 8
+	argon2idMaxSaltLength = 64
+	argon2idMinKeyLength  = 16
+	argon2idMaxKeyLength  = 128
+)
+
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type Argon2idHasher struct {
+	params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) (*Argon2idHasher, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Argon2idHasher{params}, nil
+}
+
+func (hasher *Argon2idHasher) Algorithm() string {
+	return "argon2id"
+}
+
+func (hasher *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, hasher.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey(
+		[]byte(password),
+		salt,
+		hasher.params.Iterations,
+		hasher.params.Memory,
+		hasher.params.Parallelism,
+		hasher.params.KeyLength,
+	)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		hasher.params.Memory,
+		hasher.params.Iterations,
+		hasher.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (hasher *Argon2idHasher) Verify(password, encodedHash string) (bool, error) {
+	params, salt, key, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+}
+
+func (hasher *Argon2idHasher) Supports(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$argon2id$")
+}
+
+func (hasher *Argon2idHasher) NeedsRehash(encodedHash string) bool {
+	params, salt, _, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != hasher.params.Memory ||
+		params.Iterations != hasher.params.Iterations ||
+		params.Parallelism != hasher.params.Parallelism ||
+		params.KeyLength != hasher.params.KeyLength ||
+		uint32(len(salt)) != hasher.params.SaltLength
+}
+
+func decodeArgon2idHash(encodedHash string) (*Argon2idParams, []byte, []byte, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	if version != argon2.Version {
+		return nil, nil, nil, ErrUnsupportedHash
+	}
+
+	params := &Argon2idParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	if err := params.validate(); err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	return params, salt, key, nil
+}
+
+func (params *Argon2idParams) validate() error {
+	if params.Parallelism == 0 {
+		return errors.New("argon2id parallelism must be at least 1")
+	}
+
+	if params.Memory < 8*uint32(params.Parallelism) || params.Memory > argon2idMaxMemory {
+		return fmt.Errorf("argon2id memory must be between %d and %d KiB", 8*uint32(params.Parallelism), argon2idMaxMemory)
+	}
+
+	if params.Iterations == 0 || params.Iterations > argon2idMaxIterations {
+		return fmt.Errorf("argon2id iterations must be between 1 and %d", argon2idMaxIterations)
+	}
+
+	if params.SaltLength < argon2idMinSaltLength || params.SaltLength > argon2idMaxSaltLength {
+		return fmt.Errorf("argon2id salt length must be between %d and %d", argon2idMinSaltLength, argon2idMaxSaltLength)
+	}
+
+	if params.KeyLength < argon2idMinKeyLength || params.KeyLength > argon2idMaxKeyLength {
+		return fmt.Errorf("argon2id key length must be between %d and %d", argon2idMinKeyLength, argon2idMaxKeyLength)
+	}
+
+	return nil
+}
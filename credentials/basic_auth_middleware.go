@@ -0,0 +1,59 @@
+package credentials
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/httpauth"
+	"github.com/rs/zerolog/log"
+)
+
+type Account struct {
+	PasswordHash string
+	Roles        []string
+	Principal    interface{}
+}
+
+type LookupAccountFunc = func(c *gin.Context, username string) (*Account, error)
+type UpdatePasswordHashFunc = func(c *gin.Context, username, passwordHash string) error
+
+func NewBasicAuthMiddleware(
+	manager *Manager,
+	lookupAccount LookupAccountFunc,
+	updatePasswordHash UpdatePasswordHashFunc,
+	opts ...httpauth.AuthOpt,
+) httpauth.Middleware {
+	return httpauth.NewBasicAuthMiddleware(
+		func(c *gin.Context, username, password string) (*httpauth.VerificationResult, error) {
+			account, err := lookupAccount(c, username)
+			if err != nil {
+				return nil, err
+			}
+
+			if account == nil {
+				manager.VerifyMissing(password) // keep response time independent of account existence
+				return &httpauth.VerificationResult{Verified: false}, nil
+			}
+
+			ok, newHash, err := manager.Verify(password, account.PasswordHash)
+			if err != nil && !ok {
+				return nil, err
+			}
+
+			if !ok {
+				return &httpauth.VerificationResult{Verified: false}, nil
+			}
+
+			if newHash != "" && updatePasswordHash != nil {
+				if err := updatePasswordHash(c, username, newHash); err != nil {
+					log.Warn().Err(err).Msg("Failed to update rehashed password")
+				}
+			}
+
+			return &httpauth.VerificationResult{
+				Verified:  true,
+				Roles:     account.Roles,
+				Principal: account.Principal,
+			}, nil
+		},
+		opts...,
+	)
+}
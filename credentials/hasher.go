@@ -0,0 +1,14 @@
+package credentials
+
+import "errors"
+
+var ErrInvalidHash = errors.New("invalid password hash format")
+var ErrUnsupportedHash = errors.New("unsupported password hash algorithm")
+
+type Hasher interface {
+	Algorithm() string
+	Hash(password string) (string, error)
+	Verify(password, encodedHash string) (bool, error)
+	Supports(encodedHash string) bool
+	NeedsRehash(encodedHash string) bool
+}
@@ -0,0 +1,100 @@
+package credentials
+
+import (
+	"errors"
+	"github.com/gookit/config/v2"
+)
+
+type Manager struct {
+	current   Hasher
+	hashers   []Hasher
+	dummyHash string
+}
+
+func NewManager() (*Manager, error) {
+	algorithm := config.String("credentials.algorithm")
+	argon2idMemory := config.Int("credentials.argon2id.memory")
+	argon2idIterations := config.Int("credentials.argon2id.iterations")
+	argon2idParallelism := config.Int("credentials.argon2id.parallelism")
+	bcryptCost := config.Int("credentials.bcrypt.cost")
+
+	if algorithm == "" {
+		algorithm = "argon2id"
+	}
+
+	argon2idParams := DefaultArgon2idParams
+	if argon2idMemory > 0 {
+		argon2idParams.Memory = uint32(argon2idMemory)
+	}
+
+	if argon2idIterations > 0 {
+		argon2idParams.Iterations = uint32(argon2idIterations)
+	}
+
+	if argon2idParallelism > 0 {
+		argon2idParams.Parallelism = uint8(argon2idParallelism)
+	}
+
+	argon2idHasher, err := NewArgon2idHasher(argon2idParams)
+	if err != nil {
+		return nil, err
+	}
+
+	bcryptHasher := NewBcryptHasher(bcryptCost)
+
+	switch algorithm {
+	case "argon2id":
+		return NewManagerWith(argon2idHasher, bcryptHasher)
+	case "bcrypt":
+		return NewManagerWith(bcryptHasher, argon2idHasher)
+	default:
+		return nil, errors.New("unknown credentials algorithm")
+	}
+}
+
+func NewManagerWith(current Hasher, legacy ...Hasher) (*Manager, error) {
+	dummyHash, err := current.Hash("dummy password")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		current:   current,
+		hashers:   append([]Hasher{current}, legacy...),
+		dummyHash: dummyHash,
+	}, nil
+}
+
+func (manager *Manager) Hash(password string) (string, error) {
+	return manager.current.Hash(password)
+}
+
+func (manager *Manager) Verify(password, encodedHash string) (bool, string, error) {
+	for _, hasher := range manager.hashers {
+		if !hasher.Supports(encodedHash) {
+			continue
+		}
+
+		ok, err := hasher.Verify(password, encodedHash)
+		if err != nil || !ok {
+			return false, "", err
+		}
+
+		if hasher != manager.current || manager.current.NeedsRehash(encodedHash) {
+			newHash, err := manager.current.Hash(password)
+			if err != nil {
+				return true, "", err
+			}
+
+			return true, newHash, nil
+		}
+
+		return true, "", nil
+	}
+
+	return false, "", ErrUnsupportedHash
+}
+
+func (manager *Manager) VerifyMissing(password string) {
+	_, _ = manager.current.Verify(password, manager.dummyHash)
+}
@@ -0,0 +1,9 @@
+package httpauth
+
+import "github.com/gin-gonic/gin"
+
+type VerifyAPIKeyFunc = func(c *gin.Context, apiKey string) (*VerificationResult, error)
+
+func NewAPIKeyMiddleware(headerName string, verifyAPIKey VerifyAPIKeyFunc, opts ...AuthOpt) Middleware {
+	return newSchemeMiddleware(APIKeyScheme(headerName, verifyAPIKey, opts...))
+}
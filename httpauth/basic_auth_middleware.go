@@ -0,0 +1,9 @@
+package httpauth
+
+import "github.com/gin-gonic/gin"
+
+type VerifyBasicAuthFunc = func(c *gin.Context, username, password string) (*VerificationResult, error)
+
+func NewBasicAuthMiddleware(verifyCredentials VerifyBasicAuthFunc, opts ...AuthOpt) Middleware {
+	return newSchemeMiddleware(BasicAuthScheme(verifyCredentials, opts...))
+}
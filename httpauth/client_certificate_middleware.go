@@ -0,0 +1,102 @@
+package httpauth
+
+import (
+	"crypto/x509"
+	"github.com/gin-gonic/gin"
+	"github.com/gookit/config/v2"
+	"strings"
+)
+
+type VerifyCertificateFunc = func(c *gin.Context, certificate *x509.Certificate) (*VerificationResult, error)
+
+type CertificateMapping struct {
+	Subjects map[string][]string `json:"subjects" mapstructure:"subjects"`
+	URIs     map[string][]string `json:"uris" mapstructure:"uris"`
+	DNSNames map[string][]string `json:"dnsNames" mapstructure:"dnsNames"`
+}
+
+func NewClientCertificateMiddleware(verifyCertificate VerifyCertificateFunc, opts ...AuthOpt) Middleware {
+	return newSchemeMiddleware(ClientCertificateScheme(verifyCertificate, opts...))
+}
+
+func LoadCertificateMappingFromConfig(key string) (*CertificateMapping, error) {
+	var mapping CertificateMapping
+	if err := config.BindStruct(key, &mapping); err != nil {
+		return nil, err
+	}
+
+	return &mapping, nil
+}
+
+func (mapping *CertificateMapping) Verify(_ *gin.Context, certificate *x509.Certificate) (*VerificationResult, error) {
+	matched := false
+	rolesSet := make(map[string]struct{})
+
+	addRoles := func(mappings map[string][]string, value string) {
+		for pattern, roles := range mappings {
+			if matchCertificateValue(pattern, value) {
+				matched = true
+				for _, role := range roles {
+					rolesSet[role] = struct{}{}
+				}
+			}
+		}
+	}
+
+	addRoles(mapping.Subjects, certificate.Subject.CommonName)
+	addRoles(mapping.Subjects, certificate.Subject.String())
+
+	for _, uri := range certificate.URIs {
+		addRoles(mapping.URIs, uri.String())
+	}
+
+	for _, dnsName := range certificate.DNSNames {
+		addRoles(mapping.DNSNames, dnsName)
+	}
+
+	if !matched {
+		return &VerificationResult{Verified: false}, nil
+	}
+
+	roles := make([]string, 0, len(rolesSet))
+	for role := range rolesSet {
+		roles = append(roles, role)
+	}
+
+	return &VerificationResult{
+		Verified:  true,
+		Roles:     roles,
+		Principal: certificate,
+		ExpiresAt: certificate.NotAfter,
+	}, nil
+}
+
+func SPIFFEID(certificate *x509.Certificate) string {
+	for _, uri := range certificate.URIs {
+		if uri.Scheme == "spiffe" {
+			return uri.String()
+		}
+	}
+
+	return ""
+}
+
+func extractVerifiedCertificate(c *gin.Context) *x509.Certificate {
+	if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 || len(c.Request.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return c.Request.TLS.VerifiedChains[0][0]
+}
+
+func matchCertificateValue(pattern, value string) bool {
+	if value == "" {
+		return false
+	}
+
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == value
+}
@@ -0,0 +1,43 @@
+package httpauth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/web"
+	"net/http"
+)
+
+func NewCompositeMiddleware(schemes []Scheme, opts ...AuthOpt) Middleware {
+	config := newAuthConfig(opts)
+
+	return newMiddleware(
+		func(authorizationFunc AuthorizationFunc, requiredScopes []string) gin.HandlerFunc {
+			return func(c *gin.Context) {
+				for i := range schemes {
+					if schemes[i].present(c) {
+						schemes[i].handle(c, authorizationFunc, requiredScopes)
+						return
+					}
+				}
+
+				denial := &Denial{
+					Scheme:  "composite",
+					Reason:  DenialMissingCredentials,
+					Status:  http.StatusUnauthorized,
+					Message: "Missing credentials",
+					Cause: web.FieldErrorMessage(
+						"credentials",
+						"missing",
+						"Request does not contain any supported credentials",
+					),
+				}
+
+				var challenges []string
+				for i := range schemes {
+					challenges = append(challenges, schemes[i].challenge(denial))
+				}
+
+				config.deny(c, denial, challenges)
+			}
+		},
+	)
+}
@@ -0,0 +1,250 @@
+package httpauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/web"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const csrfTokenContextKey = "httpauth.csrfToken"
+
+type CSRFTokenStore interface {
+	Token(c *gin.Context) (string, error)
+	SaveToken(c *gin.Context, token string) error
+}
+
+type csrfConfig struct {
+	store          CSRFTokenStore
+	cookieName     string
+	cookiePath     string
+	cookieDomain   string
+	cookieSecure   bool
+	cookieSameSite http.SameSite
+	cookieMaxAge   int
+	headerName     string
+	formField      string
+	verifyOrigin   bool
+	requireOrigin  bool
+	trustedOrigins map[string]struct{}
+}
+
+type CSRFOpt = func(*csrfConfig)
+
+func CSRFSynchronizerToken(store CSRFTokenStore) CSRFOpt {
+	return func(config *csrfConfig) {
+		config.store = store
+	}
+}
+
+func CSRFCookie(name, path, domain string, secure bool, sameSite http.SameSite, maxAge int) CSRFOpt {
+	return func(config *csrfConfig) {
+		config.cookieName = name
+		config.cookiePath = path
+		config.cookieDomain = domain
+		config.cookieSecure = secure
+		config.cookieSameSite = sameSite
+		config.cookieMaxAge = maxAge
+	}
+}
+
+func CSRFHeader(headerName string) CSRFOpt {
+	return func(config *csrfConfig) {
+		config.headerName = headerName
+	}
+}
+
+func CSRFFormField(formField string) CSRFOpt {
+	return func(config *csrfConfig) {
+		config.formField = formField
+	}
+}
+
+func CSRFVerifyOrigin(verifyOrigin bool) CSRFOpt {
+	return func(config *csrfConfig) {
+		config.verifyOrigin = verifyOrigin
+	}
+}
+
+func CSRFRequireOrigin(requireOrigin bool) CSRFOpt {
+	return func(config *csrfConfig) {
+		config.requireOrigin = requireOrigin
+	}
+}
+
+func CSRFTrustedOrigins(origins ...string) CSRFOpt {
+	return func(config *csrfConfig) {
+		for _, origin := range origins {
+			config.trustedOrigins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = struct{}{}
+		}
+	}
+}
+
+func NewCSRFMiddleware(opts ...CSRFOpt) gin.HandlerFunc {
+	config := &csrfConfig{
+		cookieName:     "csrf_token",
+		cookiePath:     "/",
+		cookieSecure:   true,
+		cookieSameSite: http.SameSiteLaxMode,
+		headerName:     "X-CSRF-Token",
+		formField:      "csrf_token",
+		verifyOrigin:   true,
+		trustedOrigins: make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(c *gin.Context) {
+		expectedToken, err := config.loadToken(c)
+		if err != nil {
+			web.InternalError(c, err, "Error while trying to load CSRF token")
+			c.Abort()
+			return
+		}
+
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if config.verifyOrigin {
+			if ok, cause := config.checkOrigin(c); !ok {
+				csrfError(c, cause)
+				return
+			}
+		}
+
+		submittedToken := c.GetHeader(config.headerName)
+		if submittedToken == "" && config.formField != "" && isFormRequest(c) {
+			submittedToken = c.PostForm(config.formField)
+		}
+
+		if submittedToken == "" {
+			csrfError(c, web.FieldErrorMessage("csrfToken", "missing", "CSRF token is missing"))
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(submittedToken), []byte(expectedToken)) != 1 {
+			csrfError(c, web.FieldErrorMessage("csrfToken", "invalid", "CSRF token is invalid"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func CSRFToken(c *gin.Context) string {
+	return c.GetString(csrfTokenContextKey)
+}
+
+func (config *csrfConfig) loadToken(c *gin.Context) (string, error) {
+	var token string
+
+	if config.store != nil { // synchronizer token mode
+		storedToken, err := config.store.Token(c)
+		if err != nil {
+			return "", err
+		}
+
+		token = storedToken
+		if token == "" {
+			token, err = generateCSRFToken()
+			if err != nil {
+				return "", err
+			}
+
+			if err := config.store.SaveToken(c, token); err != nil {
+				return "", err
+			}
+		}
+	} else { // double-submit cookie mode
+		cookie, err := c.Cookie(config.cookieName)
+		if err == nil && cookie != "" {
+			token = cookie
+		} else {
+			token, err = generateCSRFToken()
+			if err != nil {
+				return "", err
+			}
+
+			c.SetSameSite(config.cookieSameSite)
+			c.SetCookie(
+				config.cookieName,
+				token,
+				config.cookieMaxAge,
+				config.cookiePath,
+				config.cookieDomain,
+				config.cookieSecure,
+				false, // token must be readable by scripts to be submitted back in a header
+			)
+		}
+	}
+
+	c.Set(csrfTokenContextKey, token)
+	return token, nil
+}
+
+func (config *csrfConfig) checkOrigin(c *gin.Context) (bool, web.Cause) {
+	source := c.GetHeader("Origin")
+	if source == "" || source == "null" {
+		source = c.GetHeader("Referer")
+	}
+
+	if source == "" {
+		if config.requireOrigin {
+			return false, web.FieldErrorMessage("origin", "missing", "Request does not specify Origin or Referer")
+		}
+
+		return true, web.Cause{}
+	}
+
+	sourceURL, err := url.Parse(source)
+	if err != nil || sourceURL.Host == "" {
+		return false, web.FieldErrorMessage("origin", "invalid", "Request Origin or Referer is malformed")
+	}
+
+	if strings.EqualFold(sourceURL.Host, c.Request.Host) {
+		return true, web.Cause{}
+	}
+
+	origin := strings.ToLower(sourceURL.Scheme + "://" + sourceURL.Host)
+	if _, ok := config.trustedOrigins[origin]; ok {
+		return true, web.Cause{}
+	}
+
+	return false, web.FieldErrorMessage("origin", "untrusted", "Request Origin is not trusted")
+}
+
+func csrfError(c *gin.Context, cause web.Cause) {
+	web.ErrorResponse(c, http.StatusForbidden, "CSRF validation failed", cause)
+	c.Abort()
+}
+
+func generateCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+
+	return false
+}
+
+func isFormRequest(c *gin.Context) bool {
+	contentType := c.ContentType()
+	return contentType == gin.MIMEPOSTForm || contentType == gin.MIMEMultipartPOSTForm
+}
@@ -0,0 +1,165 @@
+package httpauth
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/web"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"strings"
+)
+
+const (
+	DenialMissingCredentials = "missing_credentials"
+	DenialInvalidCredentials = "invalid_credentials"
+	DenialInsufficientScope  = "insufficient_scope"
+)
+
+type Denial struct {
+	Scheme             string
+	Reason             string
+	Status             int
+	Message            string
+	Cause              web.Cause
+	RequiredScopes     []string
+	VerificationResult *VerificationResult
+}
+
+type DenialResponseFunc = func(c *gin.Context, denial *Denial)
+type DenialHookFunc = func(c *gin.Context, denial *Denial)
+
+type authConfig struct {
+	realm          string
+	denialResponse DenialResponseFunc
+	denialHooks    []DenialHookFunc
+	auditLog       bool
+	metrics        prometheus.Registerer
+	denialsCounter *prometheus.CounterVec
+}
+
+type AuthOpt = func(*authConfig)
+
+func Realm(realm string) AuthOpt {
+	return func(config *authConfig) {
+		config.realm = realm
+	}
+}
+
+func DenialResponse(denialResponse DenialResponseFunc) AuthOpt {
+	return func(config *authConfig) {
+		config.denialResponse = denialResponse
+	}
+}
+
+func OnDenial(hook DenialHookFunc) AuthOpt {
+	return func(config *authConfig) {
+		config.denialHooks = append(config.denialHooks, hook)
+	}
+}
+
+func AuditLog(enabled bool) AuthOpt {
+	return func(config *authConfig) {
+		config.auditLog = enabled
+	}
+}
+
+func DenialMetrics(registerer prometheus.Registerer) AuthOpt {
+	return func(config *authConfig) {
+		config.metrics = registerer
+	}
+}
+
+func newAuthConfig(opts []AuthOpt) *authConfig {
+	config := &authConfig{
+		realm:          "api",
+		denialResponse: defaultDenialResponse,
+		auditLog:       true,
+		metrics:        prometheus.DefaultRegisterer,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.metrics != nil {
+		config.denialsCounter = registerDenialsCounter(config.metrics)
+	}
+
+	return config
+}
+
+func registerDenialsCounter(registerer prometheus.Registerer) *prometheus.CounterVec {
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "httpauth_denials_total",
+			Help: "Number of requests denied by authentication middlewares",
+		},
+		[]string{"scheme", "reason"},
+	)
+
+	if err := registerer.Register(counter); err != nil {
+		if alreadyRegistered, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := alreadyRegistered.ExistingCollector.(*prometheus.CounterVec); ok {
+				return existing
+			}
+		}
+
+		log.Warn().Err(err).Msg("Failed to register httpauth denials metric")
+		return nil
+	}
+
+	return counter
+}
+
+func (config *authConfig) deny(c *gin.Context, denial *Denial, challenges []string) {
+	if config.denialsCounter != nil {
+		config.denialsCounter.WithLabelValues(denial.Scheme, denial.Reason).Inc()
+	}
+
+	if config.auditLog {
+		log.Info().
+			Str("scheme", denial.Scheme).
+			Str("reason", denial.Reason).
+			Int("status", denial.Status).
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Str("clientIp", c.ClientIP()).
+			Strs("requiredScopes", denial.RequiredScopes).
+			Msg("Request denied by authentication middleware")
+	}
+
+	for _, hook := range config.denialHooks {
+		hook(c, denial)
+	}
+
+	for _, challenge := range challenges {
+		if challenge != "" {
+			c.Writer.Header().Add("WWW-Authenticate", challenge)
+		}
+	}
+
+	config.denialResponse(c, denial)
+	c.Abort()
+}
+
+func defaultDenialResponse(c *gin.Context, denial *Denial) {
+	web.ErrorResponse(c, denial.Status, denial.Message, denial.Cause)
+}
+
+func formatChallenge(authScheme string, params ...string) string {
+	var formattedParams []string
+	for i := 0; i+1 < len(params); i += 2 {
+		if params[i+1] == "" {
+			continue
+		}
+
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(params[i+1])
+		formattedParams = append(formattedParams, fmt.Sprintf(`%s="%s"`, params[i], value))
+	}
+
+	if len(formattedParams) == 0 {
+		return authScheme
+	}
+
+	return authScheme + " " + strings.Join(formattedParams, ", ")
+}
@@ -0,0 +1,100 @@
+package httpauth
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryCacheEntry struct {
+	key                string
+	verificationResult *VerificationResult
+	expiresAt          time.Time
+}
+
+type MemoryVerificationCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	mutex    sync.Mutex
+}
+
+func NewMemoryVerificationCache(capacity int) *MemoryVerificationCache {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+
+	return &MemoryVerificationCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (cache *MemoryVerificationCache) Get(_ context.Context, key string) (*VerificationResult, bool, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		cache.removeElement(element)
+		return nil, false, nil
+	}
+
+	cache.order.MoveToFront(element)
+	return entry.verificationResult, true, nil
+}
+
+func (cache *MemoryVerificationCache) Set(
+	_ context.Context,
+	key string,
+	verificationResult *VerificationResult,
+	ttl time.Duration,
+) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+
+	if element, ok := cache.entries[key]; ok {
+		entry := element.Value.(*memoryCacheEntry)
+		entry.verificationResult = verificationResult
+		entry.expiresAt = expiresAt
+		cache.order.MoveToFront(element)
+		return nil
+	}
+
+	cache.entries[key] = cache.order.PushFront(&memoryCacheEntry{
+		key:                key,
+		verificationResult: verificationResult,
+		expiresAt:          expiresAt,
+	})
+
+	for cache.order.Len() > cache.capacity {
+		cache.removeElement(cache.order.Back())
+	}
+
+	return nil
+}
+
+func (cache *MemoryVerificationCache) Delete(_ context.Context, key string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		cache.removeElement(element)
+	}
+
+	return nil
+}
+
+func (cache *MemoryVerificationCache) removeElement(element *list.Element) {
+	cache.order.Remove(element)
+	delete(cache.entries, element.Value.(*memoryCacheEntry).key)
+}
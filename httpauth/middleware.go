@@ -1,14 +1,22 @@
 package httpauth
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+const verificationResultContextKey = "httpauth.verificationResult"
 
 type VerificationResult struct {
-	Verified bool
-	Roles    []string
+	Verified  bool
+	Roles     []string
+	Principal interface{}
+	ExpiresAt time.Time
 }
 
 type RolesCheckingFunc = func(roles []string) bool
-type MiddlewareHandler = func(rolesCheckingFunc RolesCheckingFunc) gin.HandlerFunc
+type AuthorizationFunc = func(c *gin.Context, verificationResult *VerificationResult) (bool, error)
+type MiddlewareHandler = func(authorizationFunc AuthorizationFunc, requiredScopes []string) gin.HandlerFunc
 
 type Middleware struct {
 	handler MiddlewareHandler
@@ -18,14 +26,28 @@ func newMiddleware(handler MiddlewareHandler) Middleware {
 	return Middleware{handler}
 }
 
+func GetVerificationResult(c *gin.Context) *VerificationResult {
+	value, ok := c.Get(verificationResultContextKey)
+	if !ok {
+		return nil
+	}
+
+	verificationResult, ok := value.(*VerificationResult)
+	if !ok {
+		return nil
+	}
+
+	return verificationResult
+}
+
 func (middleware *Middleware) Anyone() gin.HandlerFunc {
-	return middleware.handler(func(_ []string) bool {
+	return middleware.withRolesCheck(nil, func(_ []string) bool {
 		return true
 	})
 }
 
 func (middleware *Middleware) AnyAuthenticated() gin.HandlerFunc {
-	return middleware.handler(func(_ []string) bool {
+	return middleware.withRolesCheck(nil, func(_ []string) bool {
 		return true
 	})
 }
@@ -36,7 +58,7 @@ func (middleware *Middleware) AnyOfRoles(allowedRoles ...string) gin.HandlerFunc
 		allowedRolesSet[role] = struct{}{}
 	}
 
-	return middleware.handler(func(providedRoles []string) bool {
+	return middleware.withRolesCheck(allowedRoles, func(providedRoles []string) bool {
 		hasRole := false
 		for _, role := range providedRoles {
 			if _, ok := allowedRolesSet[role]; ok {
@@ -50,7 +72,7 @@ func (middleware *Middleware) AnyOfRoles(allowedRoles ...string) gin.HandlerFunc
 }
 
 func (middleware *Middleware) AllOfRoles(requiredRoles ...string) gin.HandlerFunc {
-	return middleware.handler(func(providedRoles []string) bool {
+	return middleware.withRolesCheck(requiredRoles, func(providedRoles []string) bool {
 		for _, role := range requiredRoles {
 			hasRole := false
 			for _, providedRole := range providedRoles {
@@ -68,3 +90,40 @@ func (middleware *Middleware) AllOfRoles(requiredRoles ...string) gin.HandlerFun
 		return true
 	})
 }
+
+func (middleware *Middleware) AnyOfPermissions(policy *Policy, permissions ...string) gin.HandlerFunc {
+	return middleware.withRolesCheck(permissions, func(providedRoles []string) bool {
+		for _, permission := range permissions {
+			if policy.HasPermission(providedRoles, permission) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (middleware *Middleware) AllOfPermissions(policy *Policy, permissions ...string) gin.HandlerFunc {
+	return middleware.withRolesCheck(permissions, func(providedRoles []string) bool {
+		for _, permission := range permissions {
+			if !policy.HasPermission(providedRoles, permission) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (middleware *Middleware) Authorize(authorizationFunc AuthorizationFunc) gin.HandlerFunc {
+	return middleware.handler(authorizationFunc, nil)
+}
+
+func (middleware *Middleware) withRolesCheck(requiredScopes []string, rolesCheckingFunc RolesCheckingFunc) gin.HandlerFunc {
+	return middleware.handler(
+		func(_ *gin.Context, verificationResult *VerificationResult) (bool, error) {
+			return rolesCheckingFunc(verificationResult.Roles), nil
+		},
+		requiredScopes,
+	)
+}
@@ -0,0 +1,130 @@
+package httpauth
+
+import (
+	"fmt"
+	"github.com/gookit/config/v2"
+	"github.com/gookit/config/v2/yaml"
+	"strings"
+)
+
+type RoleDefinition struct {
+	Permissions []string `json:"permissions" mapstructure:"permissions"`
+	Inherits    []string `json:"inherits" mapstructure:"inherits"`
+}
+
+type Policy struct {
+	permissions map[string]map[string]struct{}
+}
+
+func NewPolicy(roles map[string]RoleDefinition) (*Policy, error) {
+	policy := &Policy{
+		permissions: make(map[string]map[string]struct{}),
+	}
+
+	for role := range roles {
+		permissions := make(map[string]struct{})
+		if err := collectPermissions(roles, role, permissions, make(map[string]bool)); err != nil {
+			return nil, err
+		}
+
+		policy.permissions[role] = permissions
+	}
+
+	return policy, nil
+}
+
+func LoadPolicyFromConfig(key string) (*Policy, error) {
+	var roles map[string]RoleDefinition
+	if err := config.BindStruct(key, &roles); err != nil {
+		return nil, err
+	}
+
+	return NewPolicy(roles)
+}
+
+func LoadPolicyFromFile(path string) (*Policy, error) {
+	c := config.New("policy")
+	c.AddDriver(yaml.Driver)
+
+	if err := c.LoadFiles(path); err != nil {
+		return nil, err
+	}
+
+	var roles map[string]RoleDefinition
+	if err := c.BindStruct("roles", &roles); err != nil {
+		return nil, err
+	}
+
+	return NewPolicy(roles)
+}
+
+func (policy *Policy) Permissions(roles []string) []string {
+	var result []string
+	seen := make(map[string]struct{})
+
+	for _, role := range roles {
+		for permission := range policy.permissions[role] {
+			if _, ok := seen[permission]; !ok {
+				seen[permission] = struct{}{}
+				result = append(result, permission)
+			}
+		}
+	}
+
+	return result
+}
+
+func (policy *Policy) HasPermission(roles []string, permission string) bool {
+	for _, role := range roles {
+		for granted := range policy.permissions[role] {
+			if matchPermission(granted, permission) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func collectPermissions(
+	roles map[string]RoleDefinition,
+	role string,
+	permissions map[string]struct{},
+	visiting map[string]bool,
+) error {
+	if visiting[role] {
+		return fmt.Errorf("cycle in role inheritance at role '%s'", role)
+	}
+
+	definition, ok := roles[role]
+	if !ok {
+		return fmt.Errorf("undefined role '%s'", role)
+	}
+
+	visiting[role] = true
+	defer delete(visiting, role)
+
+	for _, permission := range definition.Permissions {
+		permissions[permission] = struct{}{}
+	}
+
+	for _, parent := range definition.Inherits {
+		if err := collectPermissions(roles, parent, permissions, visiting); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func matchPermission(granted, required string) bool {
+	if granted == "*" || granted == required {
+		return true
+	}
+
+	if strings.HasSuffix(granted, ":*") {
+		return strings.HasPrefix(required, strings.TrimSuffix(granted, "*"))
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package httpauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+type redisCacheEntry struct {
+	Verified  bool            `json:"verified"`
+	Roles     []string        `json:"roles,omitempty"`
+	Principal json.RawMessage `json:"principal,omitempty"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+}
+
+type DecodePrincipalFunc = func(data []byte) (interface{}, error)
+
+type RedisVerificationCache struct {
+	client          *redis.Client
+	decodePrincipal DecodePrincipalFunc
+}
+
+func NewRedisVerificationCache(client *redis.Client, decodePrincipal DecodePrincipalFunc) *RedisVerificationCache {
+	return &RedisVerificationCache{
+		client:          client,
+		decodePrincipal: decodePrincipal,
+	}
+}
+
+func (cache *RedisVerificationCache) Get(ctx context.Context, key string) (*VerificationResult, bool, error) {
+	data, err := cache.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var entry redisCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, err
+	}
+
+	verificationResult := &VerificationResult{
+		Verified:  entry.Verified,
+		Roles:     entry.Roles,
+		ExpiresAt: entry.ExpiresAt,
+	}
+
+	if len(entry.Principal) != 0 && cache.decodePrincipal != nil {
+		principal, err := cache.decodePrincipal(entry.Principal)
+		if err != nil {
+			return nil, false, err
+		}
+
+		verificationResult.Principal = principal
+	}
+
+	return verificationResult, true, nil
+}
+
+func (cache *RedisVerificationCache) Set(
+	ctx context.Context,
+	key string,
+	verificationResult *VerificationResult,
+	ttl time.Duration,
+) error {
+	entry := redisCacheEntry{}
+
+	if verificationResult != nil {
+		entry.Verified = verificationResult.Verified
+		entry.Roles = verificationResult.Roles
+		entry.ExpiresAt = verificationResult.ExpiresAt
+
+		if verificationResult.Principal != nil {
+			principal, err := json.Marshal(verificationResult.Principal)
+			if err != nil {
+				return err
+			}
+
+			entry.Principal = principal
+		}
+	}
+
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	return cache.client.Set(ctx, key, data, ttl).Err()
+}
+
+func (cache *RedisVerificationCache) Delete(ctx context.Context, key string) error {
+	return cache.client.Del(ctx, key).Err()
+}
@@ -0,0 +1,249 @@
+package httpauth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/web"
+	"net/http"
+	"strings"
+)
+
+type Scheme struct {
+	name              string
+	authScheme        string
+	config            *authConfig
+	present           func(c *gin.Context) bool
+	verify            func(c *gin.Context) (*VerificationResult, error)
+	errorMessage      string
+	invalidMessage    string
+	unverifiedCause   web.Cause
+	unauthorizedCause web.Cause
+}
+
+func BearerTokenScheme(verifyToken VerifyTokenFunc, opts ...AuthOpt) Scheme {
+	return Scheme{
+		name:       "bearer",
+		authScheme: "Bearer",
+		config:     newAuthConfig(opts),
+		present: func(c *gin.Context) bool {
+			return extractToken(c) != ""
+		},
+		verify: func(c *gin.Context) (*VerificationResult, error) {
+			return verifyToken(c, extractToken(c))
+		},
+		errorMessage:   "Error while trying to verify token",
+		invalidMessage: "Invalid token",
+		unverifiedCause: web.FieldErrorMessage(
+			"token",
+			"unverified",
+			"Token cannot be verified",
+		),
+		unauthorizedCause: web.FieldErrorMessage(
+			"token",
+			"unauthorized",
+			"Token does not grant the role required to access",
+		),
+	}
+}
+
+func SessionCookieScheme(cookieName string, verifyCookie VerifyCookieFunc, opts ...AuthOpt) Scheme {
+	return Scheme{
+		name:   "cookie",
+		config: newAuthConfig(opts),
+		present: func(c *gin.Context) bool {
+			return extractCookie(c, cookieName) != ""
+		},
+		verify: func(c *gin.Context) (*VerificationResult, error) {
+			return verifyCookie(c, extractCookie(c, cookieName))
+		},
+		errorMessage:   "Error while trying to verify cookie",
+		invalidMessage: "Invalid session cookie",
+		unverifiedCause: web.FieldErrorMessage(
+			"cookie",
+			"unverified",
+			"Session cookie cannot be verified",
+		),
+		unauthorizedCause: web.FieldErrorMessage(
+			"cookie",
+			"unauthorized",
+			"Session cookie does not grant the role required to access",
+		),
+	}
+}
+
+func APIKeyScheme(headerName string, verifyAPIKey VerifyAPIKeyFunc, opts ...AuthOpt) Scheme {
+	return Scheme{
+		name:   "apiKey",
+		config: newAuthConfig(opts),
+		present: func(c *gin.Context) bool {
+			return c.GetHeader(headerName) != ""
+		},
+		verify: func(c *gin.Context) (*VerificationResult, error) {
+			return verifyAPIKey(c, c.GetHeader(headerName))
+		},
+		errorMessage:   "Error while trying to verify API key",
+		invalidMessage: "Invalid API key",
+		unverifiedCause: web.FieldErrorMessage(
+			"apiKey",
+			"unverified",
+			"API key cannot be verified",
+		),
+		unauthorizedCause: web.FieldErrorMessage(
+			"apiKey",
+			"unauthorized",
+			"API key does not grant the role required to access",
+		),
+	}
+}
+
+func BasicAuthScheme(verifyCredentials VerifyBasicAuthFunc, opts ...AuthOpt) Scheme {
+	return Scheme{
+		name:       "basic",
+		authScheme: "Basic",
+		config:     newAuthConfig(opts),
+		present: func(c *gin.Context) bool {
+			_, _, ok := c.Request.BasicAuth()
+			return ok
+		},
+		verify: func(c *gin.Context) (*VerificationResult, error) {
+			username, password, _ := c.Request.BasicAuth()
+			return verifyCredentials(c, username, password)
+		},
+		errorMessage:   "Error while trying to verify credentials",
+		invalidMessage: "Invalid credentials",
+		unverifiedCause: web.FieldErrorMessage(
+			"credentials",
+			"unverified",
+			"Credentials cannot be verified",
+		),
+		unauthorizedCause: web.FieldErrorMessage(
+			"credentials",
+			"unauthorized",
+			"Credentials do not grant the role required to access",
+		),
+	}
+}
+
+func ClientCertificateScheme(verifyCertificate VerifyCertificateFunc, opts ...AuthOpt) Scheme {
+	return Scheme{
+		name:   "clientCertificate",
+		config: newAuthConfig(opts),
+		present: func(c *gin.Context) bool {
+			return c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0
+		},
+		verify: func(c *gin.Context) (*VerificationResult, error) {
+			certificate := extractVerifiedCertificate(c)
+			if certificate == nil {
+				return &VerificationResult{Verified: false}, nil
+			}
+
+			return verifyCertificate(c, certificate)
+		},
+		errorMessage:   "Error while trying to verify client certificate",
+		invalidMessage: "Invalid client certificate",
+		unverifiedCause: web.FieldErrorMessage(
+			"certificate",
+			"unverified",
+			"Client certificate cannot be verified",
+		),
+		unauthorizedCause: web.FieldErrorMessage(
+			"certificate",
+			"unauthorized",
+			"Client certificate does not grant the role required to access",
+		),
+	}
+}
+
+func (scheme *Scheme) handle(c *gin.Context, authorizationFunc AuthorizationFunc, requiredScopes []string) {
+	verificationResult, err := scheme.verify(c)
+	if err != nil {
+		web.InternalError(c, err, scheme.errorMessage)
+		c.Abort()
+		return
+	}
+
+	if verificationResult == nil || !verificationResult.Verified {
+		reason := DenialInvalidCredentials
+		if !scheme.present(c) {
+			reason = DenialMissingCredentials
+		}
+
+		scheme.deny(c, &Denial{
+			Scheme:             scheme.name,
+			Reason:             reason,
+			Status:             http.StatusUnauthorized,
+			Message:            scheme.invalidMessage,
+			Cause:              scheme.unverifiedCause,
+			VerificationResult: verificationResult,
+		})
+		return
+	}
+
+	c.Set(verificationResultContextKey, verificationResult)
+
+	authorized, err := authorizationFunc(c, verificationResult)
+	if err != nil {
+		web.InternalError(c, err, "Error while trying to authorize request")
+		c.Abort()
+		return
+	}
+
+	if !authorized {
+		scheme.deny(c, &Denial{
+			Scheme:             scheme.name,
+			Reason:             DenialInsufficientScope,
+			Status:             http.StatusForbidden,
+			Message:            "Access Denied",
+			Cause:              scheme.unauthorizedCause,
+			RequiredScopes:     requiredScopes,
+			VerificationResult: verificationResult,
+		})
+		return
+	}
+
+	c.Next()
+}
+
+func (scheme *Scheme) deny(c *gin.Context, denial *Denial) {
+	scheme.config.deny(c, denial, []string{scheme.challenge(denial)})
+}
+
+func (scheme *Scheme) challenge(denial *Denial) string {
+	switch scheme.authScheme {
+	case "Bearer":
+		switch denial.Reason {
+		case DenialMissingCredentials:
+			return formatChallenge("Bearer", "realm", scheme.config.realm)
+		case DenialInvalidCredentials:
+			return formatChallenge(
+				"Bearer",
+				"realm", scheme.config.realm,
+				"error", "invalid_token",
+				"error_description", denial.Cause.Message,
+			)
+		case DenialInsufficientScope:
+			return formatChallenge(
+				"Bearer",
+				"realm", scheme.config.realm,
+				"error", "insufficient_scope",
+				"error_description", denial.Cause.Message,
+				"scope", strings.Join(denial.RequiredScopes, " "),
+			)
+		}
+	case "Basic":
+		if denial.Status == http.StatusUnauthorized {
+			return formatChallenge("Basic", "realm", scheme.config.realm, "charset", "UTF-8")
+		}
+	}
+
+	return ""
+}
+
+func newSchemeMiddleware(scheme Scheme) Middleware {
+	return newMiddleware(
+		func(authorizationFunc AuthorizationFunc, requiredScopes []string) gin.HandlerFunc {
+			return func(c *gin.Context) {
+				scheme.handle(c, authorizationFunc, requiredScopes)
+			}
+		},
+	)
+}
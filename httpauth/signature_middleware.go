@@ -0,0 +1,294 @@
+package httpauth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/mkorman9/go-commons/web"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SignaturePayloadFunc = func(timestamp string, body []byte) []byte
+
+type ReplayStore interface {
+	MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+type signatureConfig struct {
+	secrets            [][]byte
+	algorithm          crypto.Hash
+	signatureHeader    string
+	signaturePrefix    string
+	base64Encoding     bool
+	timestampHeader    string
+	timestampTolerance time.Duration
+	payload            SignaturePayloadFunc
+	replayStore        ReplayStore
+	maxBodySize        int64
+}
+
+type SignatureOpt = func(*signatureConfig)
+
+func SignatureSecrets(secrets ...string) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.secrets = nil
+		for _, secret := range secrets {
+			config.secrets = append(config.secrets, []byte(secret))
+		}
+	}
+}
+
+func SignatureAlgorithm(algorithm crypto.Hash) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.algorithm = algorithm
+	}
+}
+
+func SignatureHeader(headerName, prefix string) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.signatureHeader = headerName
+		config.signaturePrefix = prefix
+	}
+}
+
+func SignatureBase64() SignatureOpt {
+	return func(config *signatureConfig) {
+		config.base64Encoding = true
+	}
+}
+
+func SignatureTimestamp(headerName string, tolerance time.Duration) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.timestampHeader = headerName
+		config.timestampTolerance = tolerance
+	}
+}
+
+func SignaturePayload(payload SignaturePayloadFunc) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.payload = payload
+	}
+}
+
+func SignatureReplayStore(replayStore ReplayStore) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.replayStore = replayStore
+	}
+}
+
+func SignatureMaxBodySize(maxBodySize int64) SignatureOpt {
+	return func(config *signatureConfig) {
+		config.maxBodySize = maxBodySize
+	}
+}
+
+func NewSignatureMiddleware(opts ...SignatureOpt) (gin.HandlerFunc, error) {
+	config := &signatureConfig{
+		algorithm:          crypto.SHA256,
+		signatureHeader:    "X-Signature",
+		timestampTolerance: 5 * time.Minute,
+		payload:            defaultSignaturePayload,
+		maxBodySize:        1 << 20,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if len(config.secrets) == 0 {
+		return nil, errors.New("signature secrets cannot be empty")
+	}
+
+	if !config.algorithm.Available() {
+		return nil, errors.New("signature algorithm is not available")
+	}
+
+	return func(c *gin.Context) {
+		signatureValue := strings.TrimPrefix(c.GetHeader(config.signatureHeader), config.signaturePrefix)
+		if signatureValue == "" {
+			signatureError(c, http.StatusUnauthorized, "signature", "missing", "Request signature is missing")
+			return
+		}
+
+		signature, err := config.decodeSignature(signatureValue)
+		if err != nil {
+			signatureError(c, http.StatusUnauthorized, "signature", "malformed", "Request signature is malformed")
+			return
+		}
+
+		var timestamp string
+		if config.timestampHeader != "" {
+			timestamp = c.GetHeader(config.timestampHeader)
+			if timestamp == "" {
+				signatureError(c, http.StatusUnauthorized, "timestamp", "missing", "Request timestamp is missing")
+				return
+			}
+
+			if !config.timestampValid(timestamp) {
+				signatureError(c, http.StatusUnauthorized, "timestamp", "expired", "Request timestamp is outside of tolerance")
+				return
+			}
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, config.maxBodySize+1))
+		if err != nil {
+			web.InternalError(c, err, "Error while reading signed request body")
+			c.Abort()
+			return
+		}
+
+		if int64(len(body)) > config.maxBodySize {
+			signatureError(c, http.StatusRequestEntityTooLarge, "body", "tooLarge", "Request body is too large")
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if !config.verify(config.payload(timestamp, body), signature) {
+			signatureError(c, http.StatusUnauthorized, "signature", "invalid", "Request signature is invalid")
+			return
+		}
+
+		if config.replayStore != nil {
+			ttl := config.timestampTolerance * 2
+			if config.timestampHeader == "" || ttl <= 0 {
+				ttl = 24 * time.Hour
+			}
+
+			firstSeen, err := config.replayStore.MarkSeen(c, hex.EncodeToString(signature), ttl)
+			if err != nil {
+				web.InternalError(c, err, "Error while checking request signature for replay")
+				c.Abort()
+				return
+			}
+
+			if !firstSeen {
+				signatureError(c, http.StatusUnauthorized, "signature", "replayed", "Request has already been processed")
+				return
+			}
+		}
+
+		c.Next()
+	}, nil
+}
+
+func (config *signatureConfig) decodeSignature(value string) ([]byte, error) {
+	if config.base64Encoding {
+		return base64.StdEncoding.DecodeString(value)
+	}
+
+	return hex.DecodeString(value)
+}
+
+func (config *signatureConfig) timestampValid(timestamp string) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	delta := time.Since(time.Unix(seconds, 0))
+	if delta < 0 {
+		delta = -delta
+	}
+
+	return delta <= config.timestampTolerance
+}
+
+func (config *signatureConfig) verify(payload, signature []byte) bool {
+	for _, secret := range config.secrets {
+		mac := hmac.New(config.algorithm.New, secret)
+		mac.Write(payload)
+
+		if hmac.Equal(mac.Sum(nil), signature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func defaultSignaturePayload(timestamp string, body []byte) []byte {
+	if timestamp == "" {
+		return body
+	}
+
+	return append([]byte(timestamp+"."), body...)
+}
+
+func signatureError(c *gin.Context, status int, field, code, message string) {
+	web.ErrorResponse(c, status, "Invalid request signature", web.FieldErrorMessage(field, code, message))
+	c.Abort()
+}
+
+const memoryReplayStoreSweepInterval = time.Minute
+
+type MemoryReplayStore struct {
+	seen      map[string]time.Time
+	lastSweep time.Time
+	mutex     sync.Mutex
+}
+
+func NewMemoryReplayStore() *MemoryReplayStore {
+	return &MemoryReplayStore{
+		seen:      make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (store *MemoryReplayStore) MarkSeen(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(store.lastSweep) >= memoryReplayStoreSweepInterval {
+		for k, expiresAt := range store.seen {
+			if now.After(expiresAt) {
+				delete(store.seen, k)
+			}
+		}
+
+		store.lastSweep = now
+	}
+
+	if expiresAt, ok := store.seen[key]; ok && !now.After(expiresAt) {
+		return false, nil
+	}
+
+	store.seen[key] = now.Add(ttl)
+	return true, nil
+}
+
+type RedisReplayStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+func NewRedisReplayStore(client *redis.Client, keyPrefix string) *RedisReplayStore {
+	return &RedisReplayStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func (store *RedisReplayStore) MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := store.client.SetNX(ctx, store.keyPrefix+key, 1, ttl).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,122 @@
+package httpauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+type VerifyFunc = func(c *gin.Context, credential string) (*VerificationResult, error)
+
+type VerificationCache interface {
+	Get(ctx context.Context, key string) (*VerificationResult, bool, error)
+	Set(ctx context.Context, key string, verificationResult *VerificationResult, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type cachedVerifierConfig struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	keyPrefix   string
+}
+
+type CachedVerifierOpt = func(*cachedVerifierConfig)
+
+func CacheTTL(ttl time.Duration) CachedVerifierOpt {
+	return func(config *cachedVerifierConfig) {
+		config.ttl = ttl
+	}
+}
+
+func CacheNegativeTTL(negativeTTL time.Duration) CachedVerifierOpt {
+	return func(config *cachedVerifierConfig) {
+		config.negativeTTL = negativeTTL
+	}
+}
+
+func CacheKeyPrefix(keyPrefix string) CachedVerifierOpt {
+	return func(config *cachedVerifierConfig) {
+		config.keyPrefix = keyPrefix
+	}
+}
+
+type CachedVerifier struct {
+	cache  VerificationCache
+	config *cachedVerifierConfig
+}
+
+func NewCachedVerifier(cache VerificationCache, opts ...CachedVerifierOpt) *CachedVerifier {
+	config := &cachedVerifierConfig{
+		ttl:         5 * time.Minute,
+		negativeTTL: 30 * time.Second,
+		keyPrefix:   "httpauth:",
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &CachedVerifier{
+		cache:  cache,
+		config: config,
+	}
+}
+
+func (verifier *CachedVerifier) Wrap(verify VerifyFunc) VerifyFunc {
+	return func(c *gin.Context, credential string) (*VerificationResult, error) {
+		if credential == "" {
+			return verify(c, credential)
+		}
+
+		key := verifier.key(credential)
+
+		cached, found, err := verifier.cache.Get(c, key)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to read verification result from cache")
+		} else if found {
+			return cached, nil
+		}
+
+		verificationResult, err := verify(c, credential)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl := verifier.ttlFor(verificationResult)
+		if ttl > 0 {
+			if err := verifier.cache.Set(c, key, verificationResult, ttl); err != nil {
+				log.Warn().Err(err).Msg("Failed to store verification result in cache")
+			}
+		}
+
+		return verificationResult, nil
+	}
+}
+
+func (verifier *CachedVerifier) Invalidate(ctx context.Context, credential string) error {
+	return verifier.cache.Delete(ctx, verifier.key(credential))
+}
+
+func (verifier *CachedVerifier) ttlFor(verificationResult *VerificationResult) time.Duration {
+	if verificationResult == nil || !verificationResult.Verified {
+		return verifier.config.negativeTTL
+	}
+
+	ttl := verifier.config.ttl
+	if !verificationResult.ExpiresAt.IsZero() {
+		untilExpiry := time.Until(verificationResult.ExpiresAt)
+		if untilExpiry < ttl {
+			ttl = untilExpiry
+		}
+	}
+
+	return ttl
+}
+
+func (verifier *CachedVerifier) key(credential string) string {
+	hash := sha256.Sum256([]byte(credential))
+	return verifier.config.keyPrefix + hex.EncodeToString(hash[:])
+}
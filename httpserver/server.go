@@ -2,11 +2,15 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gookit/config/v2"
 	"github.com/mkorman9/go-commons/web"
 	"github.com/rs/zerolog/log"
 	"net/http"
+	"os"
 )
 
 type Server struct {
@@ -14,6 +18,14 @@ type Server struct {
 	HttpServer *http.Server
 
 	address string
+	tls     *tlsConfig
+}
+
+type tlsConfig struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	clientAuth   string
 }
 
 func NewServer() *Server {
@@ -33,6 +45,16 @@ func NewServer() *Server {
 		mode = "release"
 	}
 
+	var tlsSettings *tlsConfig
+	if config.Bool("server.http.tls.enabled") {
+		tlsSettings = &tlsConfig{
+			certFile:     config.String("server.http.tls.cert"),
+			keyFile:      config.String("server.http.tls.key"),
+			clientCAFile: config.String("server.http.tls.clientCA"),
+			clientAuth:   config.String("server.http.tls.clientAuth"),
+		}
+	}
+
 	gin.SetMode(mode)
 
 	engine := createEngine(trustedProxies)
@@ -44,6 +66,7 @@ func NewServer() *Server {
 			Handler: engine,
 		},
 		address: address,
+		tls:     tlsSettings,
 	}
 }
 
@@ -63,14 +86,72 @@ func (server *Server) Stop() {
 }
 
 func (server *Server) runServer(errorChannel chan<- error) {
-	log.Info().Msgf("Started HTTP server on %v", server.address)
+	if server.tls != nil {
+		if err := server.configureTLS(); err != nil {
+			errorChannel <- err
+			return
+		}
+	}
+
+	var err error
+	if server.tls != nil {
+		log.Info().Msgf("Started HTTPS server on %v", server.address)
+		err = server.HttpServer.ListenAndServeTLS(server.tls.certFile, server.tls.keyFile)
+	} else {
+		log.Info().Msgf("Started HTTP server on %v", server.address)
+		err = server.HttpServer.ListenAndServe()
+	}
 
-	err := server.HttpServer.ListenAndServe()
 	if err != nil && err != http.ErrServerClosed {
 		errorChannel <- err
 	}
 }
 
+func (server *Server) configureTLS() error {
+	if server.tls.certFile == "" || server.tls.keyFile == "" {
+		return errors.New("server.http.tls.cert and server.http.tls.key cannot be empty")
+	}
+
+	tlsConf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	switch server.tls.clientAuth {
+	case "", "none":
+		tlsConf.ClientAuth = tls.NoClientCert
+	case "request":
+		tlsConf.ClientAuth = tls.RequestClientCert
+	case "verify":
+		tlsConf.ClientAuth = tls.VerifyClientCertIfGiven
+	case "require":
+		tlsConf.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return errors.New("unknown server.http.tls.clientAuth mode")
+	}
+
+	verifiesClientCert := tlsConf.ClientAuth == tls.VerifyClientCertIfGiven || tlsConf.ClientAuth == tls.RequireAndVerifyClientCert
+	if verifiesClientCert && server.tls.clientCAFile == "" {
+		return errors.New("server.http.tls.clientCA is required when server.http.tls.clientAuth verifies certificates")
+	}
+
+	if server.tls.clientCAFile != "" {
+		clientCA, err := os.ReadFile(server.tls.clientCAFile)
+		if err != nil {
+			return err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(clientCA) {
+			return errors.New("no valid certificates in server.http.tls.clientCA")
+		}
+
+		tlsConf.ClientCAs = pool
+	}
+
+	server.HttpServer.TLSConfig = tlsConf
+	return nil
+}
+
 func createEngine(trustedProxies []string) *gin.Engine {
 	engine := gin.New()
 
@@ -0,0 +1,314 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/web"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	VersionByPath = iota
+	VersionByHeader
+	VersionByMediaType
+)
+
+const apiVersionContextKey = "httpserver.apiVersion"
+
+type versionRequestKey struct{}
+
+type versionRequest struct {
+	requested string
+	supported bool
+}
+
+type APIVersions struct {
+	engine         *gin.Engine
+	basePath       string
+	strategy       int
+	headerName     string
+	mediaType      string
+	defaultVersion string
+	versions       map[string]*apiVersion
+	routes         [][]string
+	routesOnce     sync.Once
+}
+
+type apiVersion struct {
+	name            string
+	deprecatedSince time.Time
+	sunsetAt        time.Time
+	deprecationLink string
+}
+
+type VersioningOpt = func(*APIVersions)
+
+type VersionOpt = func(*apiVersion)
+
+func VersioningStrategy(strategy int) VersioningOpt {
+	return func(versions *APIVersions) {
+		versions.strategy = strategy
+	}
+}
+
+func VersionHeader(headerName string) VersioningOpt {
+	return func(versions *APIVersions) {
+		versions.headerName = headerName
+	}
+}
+
+func VersionMediaType(mediaType string) VersioningOpt {
+	return func(versions *APIVersions) {
+		versions.mediaType = mediaType
+	}
+}
+
+func DefaultVersion(name string) VersioningOpt {
+	return func(versions *APIVersions) {
+		versions.defaultVersion = name
+	}
+}
+
+func DeprecatedSince(deprecatedSince time.Time) VersionOpt {
+	return func(version *apiVersion) {
+		version.deprecatedSince = deprecatedSince
+	}
+}
+
+func SunsetAt(sunsetAt time.Time) VersionOpt {
+	return func(version *apiVersion) {
+		version.sunsetAt = sunsetAt
+	}
+}
+
+func DeprecationLink(link string) VersionOpt {
+	return func(version *apiVersion) {
+		version.deprecationLink = link
+	}
+}
+
+func (server *Server) Versioned(basePath string, opts ...VersioningOpt) *APIVersions {
+	versions := &APIVersions{
+		engine:     server.Engine,
+		basePath:   strings.TrimSuffix(basePath, "/"),
+		strategy:   VersionByPath,
+		headerName: "API-Version",
+		versions:   make(map[string]*apiVersion),
+	}
+
+	for _, opt := range opts {
+		opt(versions)
+	}
+
+	server.HttpServer.Handler = versions.handler(server.HttpServer.Handler)
+	return versions
+}
+
+func (versions *APIVersions) Version(name string, opts ...VersionOpt) *gin.RouterGroup {
+	version := &apiVersion{name: name}
+	for _, opt := range opts {
+		opt(version)
+	}
+
+	versions.versions[name] = version
+	if versions.defaultVersion == "" {
+		versions.defaultVersion = name
+	}
+
+	return versions.engine.Group(versions.basePath+"/"+name, versions.middleware(version))
+}
+
+func APIVersion(c *gin.Context) string {
+	return c.GetString(apiVersionContextKey)
+}
+
+func (versions *APIVersions) middleware(version *apiVersion) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if request, ok := c.Request.Context().Value(versionRequestKey{}).(*versionRequest); ok && !request.supported {
+			web.ErrorResponse(
+				c,
+				http.StatusBadRequest,
+				"Unsupported API version",
+				web.FieldErrorMessage(versions.versionSource(), "oneof", fmt.Sprintf("Version %q is not supported", request.requested)),
+			)
+			c.Abort()
+			return
+		}
+
+		c.Set(apiVersionContextKey, version.name)
+		c.Header(versions.headerName, version.name)
+
+		if !version.deprecatedSince.IsZero() {
+			c.Header("Deprecation", fmt.Sprintf("@%d", version.deprecatedSince.Unix()))
+		}
+		if !version.sunsetAt.IsZero() {
+			c.Header("Sunset", version.sunsetAt.UTC().Format(http.TimeFormat))
+		}
+		if version.deprecationLink != "" {
+			c.Writer.Header().Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, version.deprecationLink))
+		}
+
+		c.Next()
+	}
+}
+
+func (versions *APIVersions) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := versions.relativePath(r.URL.Path)
+		if !ok || len(versions.versions) == 0 || versions.hasRoute(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch versions.strategy {
+		case VersionByHeader:
+			w.Header().Add("Vary", versions.headerName)
+		case VersionByMediaType:
+			w.Header().Add("Vary", "Accept")
+		}
+
+		requested := versions.requestedVersion(r)
+		version := versions.resolve(requested)
+		request := &versionRequest{requested: requested, supported: version != ""}
+		if version == "" {
+			version = versions.defaultVersion
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), versionRequestKey{}, request))
+		r.URL.Path = versions.basePath + "/" + version + rest
+		r.URL.RawPath = ""
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (versions *APIVersions) hasRoute(path string) bool {
+	versions.routesOnce.Do(func() {
+		seen := make(map[string]bool)
+		for _, route := range versions.engine.Routes() {
+			if !seen[route.Path] {
+				seen[route.Path] = true
+				versions.routes = append(versions.routes, splitPath(route.Path))
+			}
+		}
+	})
+
+	pathSegments := splitPath(path)
+	for _, patternSegments := range versions.routes {
+		if routeMatches(patternSegments, pathSegments) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func routeMatches(patternSegments, pathSegments []string) bool {
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "*") {
+			return true
+		}
+
+		if i >= len(pathSegments) {
+			return false
+		}
+
+		if strings.HasPrefix(segment, ":") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+
+	return len(patternSegments) == len(pathSegments)
+}
+
+func (versions *APIVersions) relativePath(path string) (string, bool) {
+	if versions.basePath == "" {
+		return path, true
+	}
+
+	if path != versions.basePath && !strings.HasPrefix(path, versions.basePath+"/") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(path, versions.basePath), true
+}
+
+func (versions *APIVersions) requestedVersion(r *http.Request) string {
+	switch versions.strategy {
+	case VersionByHeader:
+		return strings.TrimSpace(r.Header.Get(versions.headerName))
+	case VersionByMediaType:
+		for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+			if version := versions.mediaTypeVersion(strings.TrimSpace(accepted)); version != "" {
+				return version
+			}
+		}
+	}
+
+	return ""
+}
+
+func (versions *APIVersions) mediaTypeVersion(accepted string) string {
+	mediaType, params, err := mime.ParseMediaType(accepted)
+	if err != nil {
+		return ""
+	}
+
+	if version, ok := params["version"]; ok {
+		return version
+	}
+
+	if versions.mediaType == "" || !strings.HasPrefix(mediaType, versions.mediaType+".") {
+		return ""
+	}
+
+	version := strings.TrimPrefix(mediaType, versions.mediaType+".")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+
+	return version
+}
+
+func (versions *APIVersions) resolve(requested string) string {
+	if requested == "" {
+		return versions.defaultVersion
+	}
+
+	if _, ok := versions.versions[requested]; ok {
+		return requested
+	}
+
+	if _, ok := versions.versions["v"+requested]; ok {
+		return "v" + requested
+	}
+
+	return ""
+}
+
+func (versions *APIVersions) versionSource() string {
+	switch versions.strategy {
+	case VersionByHeader:
+		return versions.headerName
+	case VersionByMediaType:
+		return "Accept"
+	}
+
+	return "path"
+}
@@ -0,0 +1,176 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gookit/config/v2"
+	"github.com/mkorman9/go-commons/requests"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ProviderMetadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	EndSessionEndpoint    string   `json:"end_session_endpoint"`
+	SigningAlgorithms     []string `json:"id_token_signing_alg_values_supported"`
+}
+
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+type LoginResult struct {
+	Tokens   *Tokens
+	Claims   *Claims
+	ReturnTo string
+}
+
+type LoginFunc = func(c *gin.Context, result *LoginResult)
+
+type Client struct {
+	config   *clientConfig
+	metadata *ProviderMetadata
+	keys     *keySet
+	onLogin  LoginFunc
+}
+
+func NewClient(onLogin LoginFunc, opts ...ClientOpt) (*Client, error) {
+	clientConfig := &clientConfig{
+		issuer:              config.String("oidc.issuer"),
+		clientID:            config.String("oidc.client.id"),
+		clientSecret:        config.String("oidc.client.secret"),
+		redirectURL:         config.String("oidc.redirect"),
+		scopes:              config.Strings("oidc.scopes"),
+		cookieName:          "oidc_login",
+		cookieSecure:        true,
+		cookieSecret:        []byte(config.String("oidc.cookie.secret")),
+		loginTimeout:        10 * time.Minute,
+		clockSkew:           time.Minute,
+		authorizationParams: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(clientConfig)
+	}
+
+	if onLogin == nil {
+		return nil, errors.New("oidc login callback cannot be nil")
+	}
+
+	if clientConfig.issuer == "" {
+		return nil, errors.New("oidc issuer cannot be empty")
+	}
+
+	if clientConfig.clientID == "" {
+		return nil, errors.New("oidc client id cannot be empty")
+	}
+
+	if clientConfig.redirectURL == "" {
+		return nil, errors.New("oidc redirect url cannot be empty")
+	}
+
+	if len(clientConfig.scopes) == 0 {
+		clientConfig.scopes = []string{"openid", "profile", "email"}
+	}
+
+	if !containsString(clientConfig.scopes, "openid") {
+		clientConfig.scopes = append([]string{"openid"}, clientConfig.scopes...)
+	}
+
+	if len(clientConfig.cookieSecret) == 0 {
+		log.Warn().Msg("Empty oidc.cookie.secret, using random secret valid only for this instance")
+
+		clientConfig.cookieSecret = make([]byte, 32)
+		if _, err := rand.Read(clientConfig.cookieSecret); err != nil {
+			return nil, err
+		}
+	}
+
+	if clientConfig.httpClient == nil {
+		clientConfig.httpClient = requests.NewClient(requests.MaxRetries(2))
+	}
+
+	if clientConfig.tokenHTTPClient == nil {
+		clientConfig.tokenHTTPClient = requests.NewClient()
+	}
+
+	metadata, err := discover(clientConfig.httpClient, clientConfig.issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		config:   clientConfig,
+		metadata: metadata,
+		keys:     newKeySet(clientConfig.httpClient, metadata.JWKSURI),
+		onLogin:  onLogin,
+	}, nil
+}
+
+func (client *Client) Metadata() *ProviderMetadata {
+	return client.metadata
+}
+
+func discover(httpClient *requests.Client, issuer string) (*ProviderMetadata, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	request, err := requests.NewRequest(
+		requests.GET,
+		requests.URL(discoveryURL),
+		requests.Header("Accept", "application/json"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := httpClient.Send(request)
+	if err != nil {
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("oidc discovery failed with status %v", response.StatusCode)
+	}
+
+	var metadata ProviderMetadata
+	if err := requests.BindResponseJSON(response, &metadata); err != nil {
+		return nil, err
+	}
+
+	if metadata.Issuer != issuer {
+		return nil, fmt.Errorf("oidc issuer mismatch: expected '%s', got '%s'", issuer, metadata.Issuer)
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is missing required endpoints")
+	}
+
+	return &metadata, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
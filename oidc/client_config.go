@@ -0,0 +1,87 @@
+package oidc
+
+import (
+	"github.com/mkorman9/go-commons/requests"
+	"time"
+)
+
+type clientConfig struct {
+	issuer              string
+	clientID            string
+	clientSecret        string
+	redirectURL         string
+	scopes              []string
+	cookieName          string
+	cookieSecure        bool
+	cookieSecret        []byte
+	loginTimeout        time.Duration
+	clockSkew           time.Duration
+	httpClient          *requests.Client
+	tokenHTTPClient     *requests.Client
+	authorizationParams map[string]string
+}
+
+type ClientOpt = func(*clientConfig)
+
+func Issuer(issuer string) ClientOpt {
+	return func(config *clientConfig) {
+		config.issuer = issuer
+	}
+}
+
+func Credentials(clientID, clientSecret string) ClientOpt {
+	return func(config *clientConfig) {
+		config.clientID = clientID
+		config.clientSecret = clientSecret
+	}
+}
+
+func RedirectURL(redirectURL string) ClientOpt {
+	return func(config *clientConfig) {
+		config.redirectURL = redirectURL
+	}
+}
+
+func Scopes(scopes ...string) ClientOpt {
+	return func(config *clientConfig) {
+		config.scopes = scopes
+	}
+}
+
+func StateCookie(name string, secure bool, secret []byte) ClientOpt {
+	return func(config *clientConfig) {
+		config.cookieName = name
+		config.cookieSecure = secure
+		config.cookieSecret = secret
+	}
+}
+
+func LoginTimeout(loginTimeout time.Duration) ClientOpt {
+	return func(config *clientConfig) {
+		config.loginTimeout = loginTimeout
+	}
+}
+
+func ClockSkew(clockSkew time.Duration) ClientOpt {
+	return func(config *clientConfig) {
+		config.clockSkew = clockSkew
+	}
+}
+
+func HTTPClient(httpClient *requests.Client) ClientOpt {
+	return func(config *clientConfig) {
+		config.httpClient = httpClient
+	}
+}
+
+func TokenHTTPClient(tokenHTTPClient *requests.Client) ClientOpt {
+	return func(config *clientConfig) {
+		config.tokenHTTPClient = tokenHTTPClient
+	}
+}
+
+func AuthorizationParam(key, value string) ClientOpt {
+	return func(config *clientConfig) {
+		config.authorizationParams[key] = value
+	}
+}
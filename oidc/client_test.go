@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testProvider struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	tokenStatus   int
+	tokenRequests int32
+	nonce         string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &testProvider{key: key, tokenStatus: http.StatusOK}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 provider.server.URL,
+			"authorization_endpoint": provider.server.URL + "/authorize",
+			"token_endpoint":         provider.server.URL + "/token",
+			"jwks_uri":               provider.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&provider.tokenRequests, 1)
+
+		if provider.tokenStatus != http.StatusOK {
+			w.WriteHeader(provider.tokenStatus)
+			return
+		}
+
+		if r.PostFormValue("code") != "test-code" || r.PostFormValue("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     provider.signIDToken(t),
+		})
+	})
+
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+func (provider *testProvider) signIDToken(t *testing.T) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test"})
+	payload, _ := json.Marshal(map[string]interface{}{
+		"iss":   provider.server.URL,
+		"sub":   "user",
+		"aud":   "client",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": provider.nonce,
+	})
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, provider.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestClient(t *testing.T, provider *testProvider, onLogin LoginFunc) *Client {
+	client, err := NewClient(
+		onLogin,
+		Issuer(provider.server.URL),
+		Credentials("client", "secret"),
+		RedirectURL("http://localhost/callback"),
+		StateCookie("oidc_login", false, []byte("secret")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func runLoginFlow(t *testing.T, provider *testProvider, client *Client) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/login", client.LoginHandler)
+	router.GET("/callback", client.CallbackHandler)
+
+	login := httptest.NewRecorder()
+	router.ServeHTTP(login, httptest.NewRequest(http.MethodGet, "/login?returnTo=/home", nil))
+	if login.Code != http.StatusFound {
+		t.Fatalf("expected redirect, got %v", login.Code)
+	}
+
+	location, err := url.Parse(login.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.nonce = location.Query().Get("nonce")
+
+	callback := httptest.NewRequest(http.MethodGet, "/callback?code=test-code&state="+url.QueryEscape(location.Query().Get("state")), nil)
+	for _, cookie := range login.Result().Cookies() {
+		callback.AddCookie(cookie)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, callback)
+	return recorder
+}
+
+func TestLoginFlow(t *testing.T) {
+	provider := newTestProvider(t)
+
+	var result *LoginResult
+	client := newTestClient(t, provider, func(c *gin.Context, r *LoginResult) {
+		result = r
+		c.Status(http.StatusNoContent)
+	})
+
+	recorder := runLoginFlow(t, provider, client)
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("expected login to succeed, got %v: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if result == nil || result.Claims.Subject != "user" || result.ReturnTo != "/home" {
+		t.Fatalf("unexpected login result: %+v", result)
+	}
+}
+
+func TestLoginFlowDoesNotRetryTokenExchange(t *testing.T) {
+	provider := newTestProvider(t)
+	provider.tokenStatus = http.StatusBadGateway
+
+	client := newTestClient(t, provider, func(c *gin.Context, r *LoginResult) {
+		t.Fatal("login callback should not be called")
+	})
+
+	recorder := runLoginFlow(t, provider, client)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected login to fail, got %v", recorder.Code)
+	}
+
+	if requests := atomic.LoadInt32(&provider.tokenRequests); requests != 1 {
+		t.Fatalf("expected a single token request, got %v", requests)
+	}
+}
+
+func TestNewClientRejectsNilLoginCallback(t *testing.T) {
+	provider := newTestProvider(t)
+
+	if _, err := NewClient(nil, Issuer(provider.server.URL)); err == nil {
+		t.Fatal("expected an error for nil login callback")
+	}
+}
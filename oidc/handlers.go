@@ -0,0 +1,256 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/mkorman9/go-commons/requests"
+	"github.com/mkorman9/go-commons/web"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type loginState struct {
+	State        string `json:"s"`
+	Nonce        string `json:"n"`
+	CodeVerifier string `json:"v"`
+	ReturnTo     string `json:"r,omitempty"`
+	ExpiresAt    int64  `json:"e"`
+}
+
+func (client *Client) LoginHandler(c *gin.Context) {
+	state := loginState{
+		ReturnTo:  sanitizeReturnTo(c.Query("returnTo")),
+		ExpiresAt: time.Now().Add(client.config.loginTimeout).Unix(),
+	}
+
+	for _, value := range []*string{&state.State, &state.Nonce, &state.CodeVerifier} {
+		randomValue, err := randomString()
+		if err != nil {
+			web.InternalError(c, err, "Error while generating OIDC login state")
+			return
+		}
+
+		*value = randomValue
+	}
+
+	cookie, err := client.encodeState(&state)
+	if err != nil {
+		web.InternalError(c, err, "Error while encoding OIDC login state")
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(
+		client.config.cookieName,
+		cookie,
+		int(client.config.loginTimeout.Seconds()),
+		"/",
+		"",
+		client.config.cookieSecure,
+		true,
+	)
+
+	challenge := sha256.Sum256([]byte(state.CodeVerifier))
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", client.config.clientID)
+	params.Set("redirect_uri", client.config.redirectURL)
+	params.Set("scope", strings.Join(client.config.scopes, " "))
+	params.Set("state", state.State)
+	params.Set("nonce", state.Nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+	for key, value := range client.config.authorizationParams {
+		params.Set(key, value)
+	}
+
+	separator := "?"
+	if strings.Contains(client.metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	c.Redirect(http.StatusFound, client.metadata.AuthorizationEndpoint+separator+params.Encode())
+}
+
+func (client *Client) CallbackHandler(c *gin.Context) {
+	cookie, err := c.Cookie(client.config.cookieName)
+	if err != nil {
+		loginError(c, "state", "missing", "Login state is missing or expired")
+		return
+	}
+
+	c.SetCookie(client.config.cookieName, "", -1, "/", "", client.config.cookieSecure, true)
+
+	state, err := client.decodeState(cookie)
+	if err != nil {
+		loginError(c, "state", "invalid", "Login state is invalid or expired")
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(c.Query("state")), []byte(state.State)) != 1 {
+		loginError(c, "state", "mismatch", "Login state does not match")
+		return
+	}
+
+	if providerError := c.Query("error"); providerError != "" {
+		loginError(c, "provider", providerError, c.Query("error_description"))
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		loginError(c, "code", "missing", "Authorization code is missing")
+		return
+	}
+
+	tokens, err := client.exchangeCode(code, state.CodeVerifier)
+	if err != nil {
+		log.Warn().Err(err).Msg("OIDC authorization code exchange failed")
+		loginError(c, "code", "exchange", "Authorization code cannot be exchanged")
+		return
+	}
+
+	claims, err := client.VerifyIDToken(tokens.IDToken, state.Nonce)
+	if err != nil {
+		if errors.Is(err, ErrInvalidIDToken) {
+			log.Warn().Err(err).Msg("OIDC ID token rejected")
+			loginError(c, "idToken", "invalid", "ID token cannot be verified")
+			return
+		}
+
+		web.InternalError(c, err, "Error while verifying OIDC ID token")
+		return
+	}
+
+	client.onLogin(c, &LoginResult{
+		Tokens:   tokens,
+		Claims:   claims,
+		ReturnTo: state.ReturnTo,
+	})
+}
+
+func (client *Client) exchangeCode(code, codeVerifier string) (*Tokens, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", client.config.redirectURL)
+	form.Set("client_id", client.config.clientID)
+	form.Set("code_verifier", codeVerifier)
+
+	opts := []requests.RequestOpt{
+		requests.POST,
+		requests.URL(client.metadata.TokenEndpoint),
+		requests.FormBody(&form),
+		requests.Header("Accept", "application/json"),
+	}
+	if client.config.clientSecret != "" {
+		opts = append(opts, requests.BasicAuth(
+			url.QueryEscape(client.config.clientID),
+			url.QueryEscape(client.config.clientSecret),
+		))
+	}
+
+	request, err := requests.NewRequest(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.config.tokenHTTPClient.Send(request)
+	if err != nil {
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := requests.ReadResponseBody(response)
+		return nil, fmt.Errorf("token endpoint returned status %v: %s", response.StatusCode, body)
+	}
+
+	var tokens Tokens
+	if err := requests.BindResponseJSON(response, &tokens); err != nil {
+		return nil, err
+	}
+
+	if tokens.IDToken == "" {
+		return nil, errors.New("token endpoint response does not contain id_token")
+	}
+
+	return &tokens, nil
+}
+
+func (client *Client) encodeState(state *loginState) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + client.signState(encodedPayload), nil
+}
+
+func (client *Client) decodeState(cookie string) (*loginState, error) {
+	parts := strings.Split(cookie, ".")
+	if len(parts) != 2 {
+		return nil, errors.New("malformed state")
+	}
+
+	if !hmac.Equal([]byte(parts[1]), []byte(client.signState(parts[0]))) {
+		return nil, errors.New("invalid state signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var state loginState
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(time.Unix(state.ExpiresAt, 0)) {
+		return nil, errors.New("state expired")
+	}
+
+	return &state, nil
+}
+
+func (client *Client) signState(encodedPayload string) string {
+	mac := hmac.New(sha256.New, client.config.cookieSecret)
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func loginError(c *gin.Context, field, code, message string) {
+	web.ErrorResponse(c, http.StatusUnauthorized, "Login failed", web.FieldErrorMessage(field, code, message))
+}
+
+func sanitizeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") || strings.Contains(returnTo, "\\") {
+		return ""
+	}
+
+	return returnTo
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,199 @@
+package oidc
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+type Audience []string
+
+func (audience *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*audience = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+
+	*audience = multiple
+	return nil
+}
+
+type Claims struct {
+	Issuer          string   `json:"iss"`
+	Subject         string   `json:"sub"`
+	Audience        Audience `json:"aud"`
+	AuthorizedParty string   `json:"azp"`
+	ExpiresAt       int64    `json:"exp"`
+	IssuedAt        int64    `json:"iat"`
+	Nonce           string   `json:"nonce"`
+	Email           string   `json:"email"`
+	EmailVerified   bool     `json:"email_verified"`
+	Name            string   `json:"name"`
+	PreferredName   string   `json:"preferred_username"`
+
+	Raw map[string]interface{} `json:"-"`
+}
+
+type idTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+func (client *Client) VerifyIDToken(rawIDToken, nonce string) (*Claims, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidIDToken)
+	}
+
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidIDToken)
+	}
+
+	var header idTokenHeader
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidIDToken)
+	}
+
+	if len(client.metadata.SigningAlgorithms) != 0 && !containsString(client.metadata.SigningAlgorithms, header.Algorithm) {
+		return nil, fmt.Errorf("%w: algorithm '%s' is not supported by provider", ErrInvalidIDToken, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidIDToken)
+	}
+
+	key, err := client.keys.key(header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Algorithm, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed payload", ErrInvalidIDToken)
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed payload", ErrInvalidIDToken)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: malformed payload", ErrInvalidIDToken)
+	}
+
+	if err := client.validateClaims(&claims, nonce); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (client *Client) validateClaims(claims *Claims, nonce string) error {
+	now := time.Now()
+
+	if claims.Issuer != client.metadata.Issuer {
+		return fmt.Errorf("%w: issuer mismatch", ErrInvalidIDToken)
+	}
+
+	if !containsString(claims.Audience, client.config.clientID) {
+		return fmt.Errorf("%w: audience mismatch", ErrInvalidIDToken)
+	}
+
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != client.config.clientID {
+		return fmt.Errorf("%w: authorized party mismatch", ErrInvalidIDToken)
+	}
+
+	if claims.ExpiresAt == 0 || now.Add(-client.config.clockSkew).After(time.Unix(claims.ExpiresAt, 0)) {
+		return fmt.Errorf("%w: token expired", ErrInvalidIDToken)
+	}
+
+	if claims.IssuedAt != 0 && now.Add(client.config.clockSkew).Before(time.Unix(claims.IssuedAt, 0)) {
+		return fmt.Errorf("%w: token issued in the future", ErrInvalidIDToken)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	return nil
+}
+
+func verifySignature(algorithm string, key crypto.PublicKey, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch algorithm {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm '%s'", algorithm)
+	}
+
+	hasher := hash.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch algorithm[0] {
+	case 'R':
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match algorithm")
+		}
+
+		return rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+	case 'P':
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match algorithm")
+		}
+
+		return rsa.VerifyPSS(rsaKey, hash, digest, signature, nil)
+	default:
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("key type does not match algorithm")
+		}
+
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature length")
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,188 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/mkorman9/go-commons/requests"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const keySetMinRefreshInterval = 30 * time.Second
+
+type jsonWebKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type keySet struct {
+	httpClient  *requests.Client
+	jwksURI     string
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+	mutex       sync.Mutex
+}
+
+func newKeySet(httpClient *requests.Client, jwksURI string) *keySet {
+	return &keySet{
+		httpClient: httpClient,
+		jwksURI:    jwksURI,
+		keys:       make(map[string]crypto.PublicKey),
+	}
+}
+
+func (ks *keySet) key(keyID string) (crypto.PublicKey, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	if key, ok := ks.findKey(keyID); ok {
+		return key, nil
+	}
+
+	if time.Since(ks.lastRefresh) < keySetMinRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key '%s'", ErrInvalidIDToken, keyID)
+	}
+
+	if err := ks.refresh(); err != nil {
+		return nil, err
+	}
+
+	if key, ok := ks.findKey(keyID); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown signing key '%s'", ErrInvalidIDToken, keyID)
+}
+
+func (ks *keySet) findKey(keyID string) (crypto.PublicKey, bool) {
+	if keyID == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+
+	key, ok := ks.keys[keyID]
+	return key, ok
+}
+
+func (ks *keySet) refresh() error {
+	ks.lastRefresh = time.Now()
+
+	request, err := requests.NewRequest(
+		requests.GET,
+		requests.URL(ks.jwksURI),
+		requests.Header("Accept", "application/json"),
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := ks.httpClient.Send(request)
+	if err != nil {
+		if response != nil {
+			_ = response.Body.Close()
+		}
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		_ = response.Body.Close()
+		return fmt.Errorf("fetching jwks failed with status %v", response.StatusCode)
+	}
+
+	var set jsonWebKeySet
+	if err := requests.BindResponseJSON(response, &set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.KeyID] = key
+	}
+
+	ks.keys = keys
+	return nil
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s'", jwk.Curve)
+		}
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid EC public key")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type '%s'", jwk.KeyType)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,35 @@
+package tokens
+
+import (
+	"errors"
+	"github.com/mkorman9/go-commons/web"
+)
+
+type Error struct {
+	Code    string
+	Message string
+}
+
+var ErrMalformed = &Error{Code: "malformed", Message: "Token is malformed"}
+var ErrInvalidSignature = &Error{Code: "invalidSignature", Message: "Token signature is invalid"}
+var ErrUnknownKey = &Error{Code: "unknownKey", Message: "Token was signed with an unknown key"}
+var ErrExpired = &Error{Code: "expired", Message: "Token has expired"}
+var ErrWrongPurpose = &Error{Code: "wrongPurpose", Message: "Token was issued for a different purpose"}
+var ErrAlreadyUsed = &Error{Code: "alreadyUsed", Message: "Token has already been used"}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Cause(field string) web.Cause {
+	return web.FieldErrorMessage(field, e.Code, e.Message)
+}
+
+func AsCause(err error, field string) (web.Cause, bool) {
+	var tokenError *Error
+	if errors.As(err, &tokenError) {
+		return tokenError.Cause(field), true
+	}
+
+	return web.Cause{}, false
+}
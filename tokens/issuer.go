@@ -0,0 +1,217 @@
+package tokens
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gookit/config/v2"
+	uuid "github.com/satori/go.uuid"
+	"strings"
+	"time"
+)
+
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+type Token struct {
+	ID        string
+	Purpose   string
+	Subject   string
+	SingleUse bool
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+type payload struct {
+	KeyID     string `json:"kid"`
+	ID        string `json:"jti"`
+	Purpose   string `json:"pur"`
+	Subject   string `json:"sub"`
+	SingleUse bool   `json:"su,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type issuerConfig struct {
+	keys       []Key
+	usageStore UsageStore
+}
+
+type IssuerOpt = func(*issuerConfig)
+
+func Keys(keys ...Key) IssuerOpt {
+	return func(config *issuerConfig) {
+		config.keys = keys
+	}
+}
+
+func SingleUseStore(usageStore UsageStore) IssuerOpt {
+	return func(config *issuerConfig) {
+		config.usageStore = usageStore
+	}
+}
+
+type Issuer struct {
+	signingKey Key
+	keys       map[string][]byte
+	usageStore UsageStore
+}
+
+func NewIssuer(opts ...IssuerOpt) (*Issuer, error) {
+	issuerConfig := &issuerConfig{}
+
+	for _, value := range config.Strings("tokens.keys") {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.New("tokens.keys entries must be in the format id:secret")
+		}
+
+		issuerConfig.keys = append(issuerConfig.keys, Key{ID: parts[0], Secret: []byte(parts[1])})
+	}
+
+	for _, opt := range opts {
+		opt(issuerConfig)
+	}
+
+	if len(issuerConfig.keys) == 0 {
+		return nil, errors.New("tokens.keys cannot be empty")
+	}
+
+	keys := make(map[string][]byte)
+	for _, key := range issuerConfig.keys {
+		keys[key.ID] = key.Secret
+	}
+
+	return &Issuer{
+		signingKey: issuerConfig.keys[0],
+		keys:       keys,
+		usageStore: issuerConfig.usageStore,
+	}, nil
+}
+
+func (issuer *Issuer) Issue(purpose, subject string, ttl time.Duration) (string, *Token, error) {
+	return issuer.issue(purpose, subject, ttl, false)
+}
+
+func (issuer *Issuer) IssueSingleUse(purpose, subject string, ttl time.Duration) (string, *Token, error) {
+	if issuer.usageStore == nil {
+		return "", nil, errors.New("single-use tokens require a usage store")
+	}
+
+	return issuer.issue(purpose, subject, ttl, true)
+}
+
+func (issuer *Issuer) Inspect(rawToken, purpose string) (*Token, error) {
+	p, err := issuer.decode(rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Purpose != purpose {
+		return nil, ErrWrongPurpose
+	}
+
+	if time.Now().After(time.Unix(p.ExpiresAt, 0)) {
+		return nil, ErrExpired
+	}
+
+	return p.token(), nil
+}
+
+func (issuer *Issuer) Verify(ctx context.Context, rawToken, purpose string) (*Token, error) {
+	token, err := issuer.Inspect(rawToken, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.SingleUse {
+		if issuer.usageStore == nil {
+			return nil, errors.New("single-use tokens require a usage store")
+		}
+
+		firstUse, err := issuer.usageStore.MarkUsed(ctx, token.ID, token.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if !firstUse {
+			return nil, ErrAlreadyUsed
+		}
+	}
+
+	return token, nil
+}
+
+func (issuer *Issuer) issue(purpose, subject string, ttl time.Duration, singleUse bool) (string, *Token, error) {
+	now := time.Now().UTC()
+
+	p := &payload{
+		KeyID:     issuer.signingKey.ID,
+		ID:        uuid.NewV4().String(),
+		Purpose:   purpose,
+		Subject:   subject,
+		SingleUse: singleUse,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(data)
+	signature := sign(issuer.signingKey.Secret, encodedPayload)
+
+	return encodedPayload + "." + signature, p.token(), nil
+}
+
+func (issuer *Issuer) decode(rawToken string) (*payload, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 2 {
+		return nil, ErrMalformed
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, ErrMalformed
+	}
+
+	secret, ok := issuer.keys[p.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	if !hmac.Equal([]byte(parts[1]), []byte(sign(secret, parts[0]))) {
+		return nil, ErrInvalidSignature
+	}
+
+	return &p, nil
+}
+
+func (p *payload) token() *Token {
+	return &Token{
+		ID:        p.ID,
+		Purpose:   p.Purpose,
+		Subject:   p.Subject,
+		SingleUse: p.SingleUse,
+		IssuedAt:  time.Unix(p.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(p.ExpiresAt, 0).UTC(),
+	}
+}
+
+func sign(secret []byte, encodedPayload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(encodedPayload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,83 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+	"time"
+)
+
+type UsageStore interface {
+	MarkUsed(ctx context.Context, tokenID string, expiresAt time.Time) (bool, error)
+}
+
+type RedisUsageStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+func NewRedisUsageStore(client *redis.Client, keyPrefix string) *RedisUsageStore {
+	return &RedisUsageStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func (store *RedisUsageStore) MarkUsed(ctx context.Context, tokenID string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+
+	ok, err := store.client.SetNX(ctx, store.keyPrefix+tokenID, 1, ttl).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+type PostgresUsageStore struct {
+	db        *gorm.DB
+	tableName string
+}
+
+func NewPostgresUsageStore(db *gorm.DB, tableName string) *PostgresUsageStore {
+	if tableName == "" {
+		tableName = "used_tokens"
+	}
+
+	return &PostgresUsageStore{
+		db:        db,
+		tableName: tableName,
+	}
+}
+
+func (store *PostgresUsageStore) Migrate() error {
+	return store.db.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, expires_at TIMESTAMPTZ NOT NULL)",
+		store.tableName,
+	)).Error
+}
+
+func (store *PostgresUsageStore) MarkUsed(ctx context.Context, tokenID string, expiresAt time.Time) (bool, error) {
+	result := store.db.WithContext(ctx).Exec(
+		fmt.Sprintf("INSERT INTO %s (id, expires_at) VALUES (?, ?) ON CONFLICT (id) DO NOTHING", store.tableName),
+		tokenID,
+		expiresAt,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (store *PostgresUsageStore) DeleteExpired(ctx context.Context) error {
+	return store.db.WithContext(ctx).Exec(
+		fmt.Sprintf("DELETE FROM %s WHERE expires_at < ?", store.tableName),
+		time.Now().UTC(),
+	).Error
+}
@@ -1,11 +1,276 @@
 package web
 
-import "github.com/gin-gonic/gin"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"reflect"
+	"strings"
+)
 
-func BindJSONBody(c *gin.Context, val interface{}) (bool, []Cause) {
-	if err := c.ShouldBindJSON(val); err != nil {
-		return false, []Cause{FieldError("body", "json")}
+var errBodyTooLarge = errors.New("request body too large")
+
+type bindConfig struct {
+	disallowUnknownFields bool
+	disallowTrailingData  bool
+	useNumber             bool
+	maxBodySize           int64
+}
+
+type BindOpt = func(*bindConfig)
+
+func DisallowUnknownFields() BindOpt {
+	return func(config *bindConfig) {
+		config.disallowUnknownFields = true
+	}
+}
+
+func DisallowTrailingData() BindOpt {
+	return func(config *bindConfig) {
+		config.disallowTrailingData = true
+	}
+}
+
+func UseNumber() BindOpt {
+	return func(config *bindConfig) {
+		config.useNumber = true
+	}
+}
+
+func MaxBodySize(maxBodySize int64) BindOpt {
+	return func(config *bindConfig) {
+		config.maxBodySize = maxBodySize
+	}
+}
+
+func BindJSONBody(c *gin.Context, val interface{}, opts ...BindOpt) (bool, []Cause) {
+	if _, cause := decodeJSONBody(c, val, newBindConfig(opts)); cause != nil {
+		return false, []Cause{*cause}
+	}
+
+	if ok, causes := validateBindingStruct(val); !ok {
+		return false, causes
+	}
+
+	return ValidateStructFor(c, val)
+}
+
+func validateBindingStruct(val interface{}) (bool, []Cause) {
+	if binding.Validator == nil {
+		return true, nil
+	}
+
+	err := binding.Validator.ValidateStruct(val)
+	if err == nil {
+		return true, nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return false, []Cause{FieldErrorMessage("body", "json", err.Error())}
+	}
+
+	var causes []Cause
+	for _, fieldError := range validationErrors {
+		causes = append(causes, FieldError(jsonNamespace(reflect.TypeOf(val), fieldError.StructNamespace()), fieldError.Tag()))
+	}
+
+	return false, causes
+}
+
+func jsonNamespace(t reflect.Type, structNamespace string) string {
+	var names []string
+	for _, part := range strings.Split(structNamespace, ".")[1:] {
+		name, index := part, ""
+		if i := strings.Index(part, "["); i >= 0 {
+			name, index = part[:i], part[i:]
+		}
+
+		for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+			t = t.Elem()
+		}
+
+		if t == nil || t.Kind() != reflect.Struct {
+			names = append(names, part)
+			t = nil
+			continue
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok {
+			names = append(names, part)
+			t = nil
+			continue
+		}
+
+		if jsonName := jsonFieldName(field); jsonName != "" {
+			name = jsonName
+		}
+
+		names = append(names, name+index)
+		t = field.Type
+	}
+
+	return strings.Join(names, ".")
+}
+
+func newBindConfig(opts []BindOpt) *bindConfig {
+	config := &bindConfig{
+		disallowUnknownFields: binding.EnableDecoderDisallowUnknownFields,
+		useNumber:             binding.EnableDecoderUseNumber,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+func decodeJSONBody(c *gin.Context, val interface{}, config *bindConfig) ([]byte, *Cause) {
+	if c.Request == nil || c.Request.Body == nil {
+		return nil, &Cause{Field: "body", Code: "required", Message: "Request body is empty"}
+	}
+
+	var body io.Reader = c.Request.Body
+	if config.maxBodySize > 0 {
+		body = &limitedBodyReader{reader: c.Request.Body, remaining: config.maxBodySize}
+	}
+
+	var consumed bytes.Buffer
+	decoder := json.NewDecoder(io.TeeReader(body, &consumed))
+	if config.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if config.useNumber {
+		decoder.UseNumber()
+	}
+
+	if err := decoder.Decode(val); err != nil {
+		return nil, jsonDecodeCause(err, consumed.Bytes())
+	}
+
+	if config.disallowTrailingData {
+		if _, err := decoder.Token(); err != io.EOF {
+			if errors.Is(err, errBodyTooLarge) {
+				return nil, jsonDecodeCause(err, consumed.Bytes())
+			}
+
+			return nil, &Cause{Field: "body", Code: "trailing", Message: "Request body contains data after the JSON value"}
+		}
+	}
+
+	return consumed.Bytes(), nil
+}
+
+func jsonDecodeCause(err error, consumed []byte) *Cause {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, errBodyTooLarge):
+		return &Cause{Field: "body", Code: "tooLarge", Message: "Request body is too large"}
+	case errors.Is(err, io.EOF):
+		return &Cause{Field: "body", Code: "required", Message: "Request body is empty"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &Cause{Field: "body", Code: "json", Message: "Request body contains incomplete JSON"}
+	case errors.As(err, &syntaxError):
+		line, column := textPosition(consumed, syntaxError.Offset-1)
+		return &Cause{
+			Field:   "body",
+			Code:    "json",
+			Message: fmt.Sprintf("Syntax error at line %d, column %d (offset %d): %s", line, column, syntaxError.Offset, syntaxError.Error()),
+		}
+	case errors.As(err, &typeError):
+		field := typeError.Field
+		if field == "" {
+			field = "body"
+		}
+
+		return &Cause{
+			Field:   field,
+			Code:    "type",
+			Message: fmt.Sprintf("Expected %s but got %s", jsonTypeName(typeError.Type), typeError.Value),
+		}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return &Cause{Field: field, Code: "unknown", Message: "Unknown field"}
+	default:
+		return &Cause{Field: "body", Code: "json", Message: err.Error()}
+	}
+}
+
+func textPosition(data []byte, offset int64) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	line, column := 1, 1
+	for _, b := range data[:offset] {
+		if b == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	return line, column
+}
+
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "unsigned integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return t.String()
+	}
+}
+
+type limitedBodyReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *limitedBodyReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		var probe [1]byte
+		if n, _ := r.reader.Read(probe[:]); n > 0 {
+			return 0, errBodyTooLarge
+		}
+
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
 	}
 
-	return ValidateStruct(val)
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
 }
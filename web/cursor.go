@@ -1,5 +1,23 @@
 package web
 
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+)
+
+const cursorVersion = 1
+const cursorSignatureLength = 16
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
 type CursorOptions struct {
 	Cursor string
 	Limit  int
@@ -8,3 +26,170 @@ type CursorOptions struct {
 type Cursor struct {
 	NextCursor string `json:"nextCursor"`
 }
+
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret}
+}
+
+func (codec *CursorCodec) Encode(values []interface{}) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	raw := append([]byte{cursorVersion}, data...)
+	return base64.RawURLEncoding.EncodeToString(append(raw, codec.sign(raw)...)), nil
+}
+
+func (codec *CursorCodec) Decode(cursor string) ([]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) < 1+cursorSignatureLength {
+		return nil, ErrInvalidCursor
+	}
+
+	payload := raw[:len(raw)-cursorSignatureLength]
+	signature := raw[len(raw)-cursorSignatureLength:]
+
+	if !hmac.Equal(signature, codec.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	if payload[0] != cursorVersion {
+		return nil, ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload[1:]))
+	decoder.UseNumber()
+
+	var values []interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, value := range values {
+		if number, ok := value.(json.Number); ok {
+			if integer, err := number.Int64(); err == nil {
+				values[i] = integer
+			} else if float, err := number.Float64(); err == nil {
+				values[i] = float
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func (codec *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, codec.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)[:cursorSignatureLength]
+}
+
+func ParseCursorOptions(c *gin.Context, defaultLimit, maxLimit int) (CursorOptions, []Cause) {
+	opts := CursorOptions{
+		Cursor: c.Query("cursor"),
+		Limit:  defaultLimit,
+	}
+
+	if limitValue := c.Query("limit"); limitValue != "" {
+		limit, err := strconv.Atoi(limitValue)
+		if err != nil {
+			return opts, []Cause{FieldError("limit", "int")}
+		}
+
+		if limit < 1 {
+			return opts, []Cause{FieldError("limit", "min")}
+		}
+
+		if limit > maxLimit {
+			return opts, []Cause{FieldError("limit", "max")}
+		}
+
+		opts.Limit = limit
+	}
+
+	return opts, nil
+}
+
+type KeysetColumn struct {
+	Column string
+	Desc   bool
+}
+
+type Keyset struct {
+	codec   *CursorCodec
+	columns []KeysetColumn
+}
+
+func NewKeyset(codec *CursorCodec, columns ...KeysetColumn) *Keyset {
+	return &Keyset{
+		codec:   codec,
+		columns: columns,
+	}
+}
+
+func (keyset *Keyset) Scope(opts CursorOptions) (func(*gorm.DB) *gorm.DB, []Cause) {
+	var values []interface{}
+
+	if opts.Cursor != "" {
+		decoded, err := keyset.codec.Decode(opts.Cursor)
+		if err != nil || len(decoded) != len(keyset.columns) {
+			return nil, []Cause{FieldError("cursor", "invalid")}
+		}
+
+		values = decoded
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if values != nil {
+			db = db.Where(keyset.condition(values))
+		}
+
+		for _, column := range keyset.columns {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column.Column}, Desc: column.Desc})
+		}
+
+		return db.Limit(opts.Limit + 1)
+	}, nil
+}
+
+func (keyset *Keyset) condition(values []interface{}) clause.Expression {
+	var alternatives []clause.Expression
+
+	for i, column := range keyset.columns {
+		var conjunction []clause.Expression
+
+		for j := 0; j < i; j++ {
+			conjunction = append(conjunction, clause.Eq{Column: clause.Column{Name: keyset.columns[j].Column}, Value: values[j]})
+		}
+
+		if column.Desc {
+			conjunction = append(conjunction, clause.Lt{Column: clause.Column{Name: column.Column}, Value: values[i]})
+		} else {
+			conjunction = append(conjunction, clause.Gt{Column: clause.Column{Name: column.Column}, Value: values[i]})
+		}
+
+		alternatives = append(alternatives, clause.And(conjunction...))
+	}
+
+	return clause.Or(alternatives...)
+}
+
+func KeysetPage[T any](records []T, opts CursorOptions, keyset *Keyset, values func(T) []interface{}) ([]T, Cursor, error) {
+	if len(records) <= opts.Limit {
+		return records, Cursor{}, nil
+	}
+
+	records = records[:opts.Limit]
+
+	nextCursor, err := keyset.codec.Encode(values(records[len(records)-1]))
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return records, Cursor{NextCursor: nextCursor}, nil
+}
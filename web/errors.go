@@ -0,0 +1,112 @@
+package web
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sync"
+)
+
+type HTTPError struct {
+	Status  int
+	Message string
+	Causes  []Cause
+}
+
+func NewHTTPError(status int, message string, causes ...Cause) *HTTPError {
+	return &HTTPError{Status: status, Message: message, Causes: causes}
+}
+
+func (err *HTTPError) Error() string {
+	return http.StatusText(err.Status) + ": " + err.Message
+}
+
+type ErrorMapping struct {
+	Status  int
+	Message string
+	Causes  []Cause
+}
+
+type ErrorMapperFunc = func(err error) (*ErrorMapping, bool)
+
+type ErrorRegistry struct {
+	mappers []ErrorMapperFunc
+	mutex   sync.RWMutex
+}
+
+var DefaultErrorRegistry = NewErrorRegistry()
+
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{}
+}
+
+func HandleError(c *gin.Context, err error, logMessage string, v ...interface{}) {
+	DefaultErrorRegistry.HandleError(c, err, logMessage, v...)
+}
+
+func RegisterErrorType[T error](registry *ErrorRegistry, mapping func(err T) *ErrorMapping) {
+	registry.RegisterMapper(func(err error) (*ErrorMapping, bool) {
+		var target T
+		if !errors.As(err, &target) {
+			return nil, false
+		}
+
+		m := mapping(target)
+		return m, m != nil
+	})
+}
+
+func (registry *ErrorRegistry) RegisterMapper(mapper ErrorMapperFunc) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.mappers = append(registry.mappers, mapper)
+}
+
+func (registry *ErrorRegistry) RegisterError(target error, status int, message string, causes ...Cause) {
+	registry.RegisterMapper(func(err error) (*ErrorMapping, bool) {
+		if !errors.Is(err, target) {
+			return nil, false
+		}
+
+		return &ErrorMapping{Status: status, Message: message, Causes: causes}, true
+	})
+}
+
+func (registry *ErrorRegistry) RegisterPredicate(predicate func(err error) bool, status int, message string, causes ...Cause) {
+	registry.RegisterMapper(func(err error) (*ErrorMapping, bool) {
+		if !predicate(err) {
+			return nil, false
+		}
+
+		return &ErrorMapping{Status: status, Message: message, Causes: causes}, true
+	})
+}
+
+func (registry *ErrorRegistry) HandleError(c *gin.Context, err error, logMessage string, v ...interface{}) {
+	mapping, ok := registry.Resolve(err)
+	if !ok {
+		InternalError(c, err, logMessage, v...)
+		return
+	}
+
+	ErrorResponse(c, mapping.Status, mapping.Message, mapping.Causes...)
+}
+
+func (registry *ErrorRegistry) Resolve(err error) (*ErrorMapping, bool) {
+	var httpError *HTTPError
+	if errors.As(err, &httpError) {
+		return &ErrorMapping{Status: httpError.Status, Message: httpError.Message, Causes: httpError.Causes}, true
+	}
+
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	for i := len(registry.mappers) - 1; i >= 0; i-- {
+		if mapping, ok := registry.mappers[i](err); ok {
+			return mapping, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,278 @@
+package web
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const MIMENDJSON = "application/x-ndjson"
+
+type RowsIterator = func(yield func(row interface{}) error) error
+
+type exportConfig struct {
+	flushRows     int
+	flushInterval time.Duration
+	compression   bool
+	fileName      string
+}
+
+type ExportOpt = func(*exportConfig)
+
+func ExportFlushEvery(rows int) ExportOpt {
+	return func(config *exportConfig) {
+		config.flushRows = rows
+	}
+}
+
+func ExportFlushInterval(interval time.Duration) ExportOpt {
+	return func(config *exportConfig) {
+		config.flushInterval = interval
+	}
+}
+
+func ExportCompression() ExportOpt {
+	return func(config *exportConfig) {
+		config.compression = true
+	}
+}
+
+func ExportFileName(fileName string) ExportOpt {
+	return func(config *exportConfig) {
+		config.fileName = fileName
+	}
+}
+
+func GormRows[T any](db *gorm.DB) RowsIterator {
+	return func(yield func(row interface{}) error) error {
+		rows, err := db.Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item T
+			if err := db.ScanRows(rows, &item); err != nil {
+				return err
+			}
+
+			if err := yield(&item); err != nil {
+				return err
+			}
+		}
+
+		return rows.Err()
+	}
+}
+
+func SliceRows[T any](items []T) RowsIterator {
+	return func(yield func(row interface{}) error) error {
+		for i := range items {
+			if err := yield(&items[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func StreamNDJSON(c *gin.Context, iterate RowsIterator, opts ...ExportOpt) error {
+	stream := newExportStream(c, MIMENDJSON, opts)
+
+	err := iterate(func(row interface{}) error {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+
+		return stream.writeRow(append(data, '\n'))
+	})
+
+	return stream.finish(err)
+}
+
+func StreamCSV[T any](c *gin.Context, iterate RowsIterator, opts ...ExportOpt) error {
+	stream := newExportStream(c, "text/csv; charset=utf-8", opts)
+
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	writeRecord := func(record []string) error {
+		builder.Reset()
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+
+		return stream.writeRow([]byte(builder.String()))
+	}
+
+	rowType := reflect.TypeOf((*T)(nil)).Elem()
+	for rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+
+	var fields []csvField
+	if rowType.Kind() == reflect.Struct {
+		fields = csvFields(rowType)
+	}
+
+	headerWritten := false
+	writeHeader := func() error {
+		if headerWritten || fields == nil {
+			return nil
+		}
+
+		headerWritten = true
+		return writeRecord(csvHeader(fields))
+	}
+
+	err := iterate(func(row interface{}) error {
+		switch record := row.(type) {
+		case []string:
+			return writeRecord(record)
+		case *[]string:
+			return writeRecord(*record)
+		}
+
+		v := reflect.Indirect(reflect.ValueOf(row))
+		if v.Kind() != reflect.Struct || (fields != nil && v.Type() != rowType) {
+			return ErrUnsupportedValue
+		}
+
+		if fields == nil {
+			rowType = v.Type()
+			fields = csvFields(rowType)
+		}
+
+		if err := writeHeader(); err != nil {
+			return err
+		}
+
+		return writeRecord(csvRow(v, fields))
+	})
+	if err == nil {
+		err = writeHeader()
+	}
+
+	return stream.finish(err)
+}
+
+type exportStream struct {
+	c           *gin.Context
+	config      *exportConfig
+	contentType string
+	output      io.Writer
+	gzipWriter  *gzip.Writer
+	started     bool
+	pending     int
+	lastFlush   time.Time
+}
+
+func newExportStream(c *gin.Context, contentType string, opts []ExportOpt) *exportStream {
+	config := &exportConfig{
+		flushRows:     100,
+		flushInterval: time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &exportStream{
+		c:           c,
+		config:      config,
+		contentType: contentType,
+	}
+}
+
+func (stream *exportStream) start() {
+	if stream.started {
+		return
+	}
+	stream.started = true
+
+	header := stream.c.Writer.Header()
+	header.Set("Content-Type", stream.contentType)
+	header.Set("Cache-Control", "no-cache")
+	if stream.config.fileName != "" {
+		header.Set("Content-Disposition", `attachment; filename="`+strings.ReplaceAll(stream.config.fileName, `"`, "")+`"`)
+	}
+
+	stream.output = stream.c.Writer
+	if stream.config.compression && strings.Contains(stream.c.GetHeader("Accept-Encoding"), "gzip") {
+		header.Set("Content-Encoding", "gzip")
+		header.Add("Vary", "Accept-Encoding")
+		stream.gzipWriter = gzip.NewWriter(stream.c.Writer)
+		stream.output = stream.gzipWriter
+	}
+
+	stream.c.Status(http.StatusOK)
+	stream.lastFlush = time.Now()
+}
+
+func (stream *exportStream) writeRow(data []byte) error {
+	if err := stream.c.Request.Context().Err(); err != nil {
+		return err
+	}
+
+	stream.start()
+
+	if _, err := stream.output.Write(data); err != nil {
+		return err
+	}
+
+	stream.pending++
+	if (stream.config.flushRows > 0 && stream.pending >= stream.config.flushRows) ||
+		(stream.config.flushInterval > 0 && time.Since(stream.lastFlush) >= stream.config.flushInterval) {
+		return stream.flush()
+	}
+
+	return nil
+}
+
+func (stream *exportStream) flush() error {
+	if stream.gzipWriter != nil {
+		if err := stream.gzipWriter.Flush(); err != nil {
+			return err
+		}
+	}
+
+	stream.c.Writer.Flush()
+	stream.pending = 0
+	stream.lastFlush = time.Now()
+	return nil
+}
+
+func (stream *exportStream) finish(err error) error {
+	if err != nil && !stream.started {
+		return err
+	}
+
+	stream.start()
+
+	if stream.gzipWriter != nil {
+		if closeErr := stream.gzipWriter.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	if stream.c.Request.Context().Err() == nil {
+		stream.c.Writer.Flush()
+	}
+
+	return err
+}
@@ -0,0 +1,216 @@
+package web
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FilterTypeString = iota
+	FilterTypeInt    = iota
+	FilterTypeFloat  = iota
+	FilterTypeBool   = iota
+	FilterTypeTime   = iota
+)
+
+const (
+	FilterEq     = "eq"
+	FilterNe     = "ne"
+	FilterGt     = "gt"
+	FilterLt     = "lt"
+	FilterIn     = "in"
+	FilterLike   = "like"
+	FilterIsNull = "isnull"
+)
+
+var defaultFilterOperators = map[int][]string{
+	FilterTypeString: {FilterEq, FilterNe, FilterIn, FilterLike, FilterIsNull},
+	FilterTypeInt:    {FilterEq, FilterNe, FilterGt, FilterLt, FilterIn, FilterIsNull},
+	FilterTypeFloat:  {FilterEq, FilterNe, FilterGt, FilterLt, FilterIsNull},
+	FilterTypeBool:   {FilterEq, FilterNe, FilterIsNull},
+	FilterTypeTime:   {FilterEq, FilterNe, FilterGt, FilterLt, FilterIsNull},
+}
+
+type FilterField struct {
+	Name      string
+	Column    string
+	Type      int
+	Operators []string
+}
+
+type FilterFields []FilterField
+
+type Filter struct {
+	Column   string
+	Operator string
+	Values   []interface{}
+}
+
+func ParseFilters(c *gin.Context, fields FilterFields) ([]Filter, []Cause) {
+	var filters []Filter
+	var causes []Cause
+
+	for _, field := range fields {
+		for _, expression := range c.QueryArray(field.Name) {
+			filter, cause := field.parse(expression)
+			if cause != nil {
+				causes = append(causes, *cause)
+				continue
+			}
+
+			filters = append(filters, filter)
+		}
+	}
+
+	return filters, causes
+}
+
+func FilterScope(filters []Filter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, filter := range filters {
+			column := clause.Column{Name: filter.Column}
+
+			switch filter.Operator {
+			case FilterEq:
+				db = db.Where(clause.Eq{Column: column, Value: filter.Values[0]})
+			case FilterNe:
+				db = db.Where(clause.Neq{Column: column, Value: filter.Values[0]})
+			case FilterGt:
+				db = db.Where(clause.Gt{Column: column, Value: filter.Values[0]})
+			case FilterLt:
+				db = db.Where(clause.Lt{Column: column, Value: filter.Values[0]})
+			case FilterIn:
+				db = db.Where(clause.IN{Column: column, Values: filter.Values})
+			case FilterLike:
+				db = db.Where(clause.Expr{SQL: "? LIKE ? ESCAPE '\\'", Vars: []interface{}{column, filter.Values[0]}})
+			case FilterIsNull:
+				if filter.Values[0].(bool) {
+					db = db.Where(clause.Eq{Column: column, Value: nil})
+				} else {
+					db = db.Where(clause.Neq{Column: column, Value: nil})
+				}
+			}
+		}
+
+		return db
+	}
+}
+
+func (field *FilterField) parse(expression string) (Filter, *Cause) {
+	operator := FilterEq
+	value := expression
+
+	if i := strings.Index(expression, ":"); i >= 0 && isFilterOperator(expression[:i]) {
+		operator = expression[:i]
+		value = expression[i+1:]
+	}
+
+	if !field.allows(operator) {
+		cause := FieldErrorMessage(field.Name, "operator", fmt.Sprintf("Operator '%s' is not allowed", operator))
+		return Filter{}, &cause
+	}
+
+	filter := Filter{
+		Column:   field.Column,
+		Operator: operator,
+	}
+
+	switch operator {
+	case FilterIsNull:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			cause := FieldError(field.Name, "bool")
+			return Filter{}, &cause
+		}
+
+		filter.Values = []interface{}{b}
+	case FilterIn:
+		for _, item := range strings.Split(value, ",") {
+			parsed, cause := field.parseValue(item)
+			if cause != nil {
+				return Filter{}, cause
+			}
+
+			filter.Values = append(filter.Values, parsed)
+		}
+	case FilterLike:
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+		filter.Values = []interface{}{"%" + escaped + "%"}
+	default:
+		parsed, cause := field.parseValue(value)
+		if cause != nil {
+			return Filter{}, cause
+		}
+
+		filter.Values = []interface{}{parsed}
+	}
+
+	return filter, nil
+}
+
+func (field *FilterField) parseValue(value string) (interface{}, *Cause) {
+	var parsed interface{}
+	var err error
+	code := ""
+
+	switch field.Type {
+	case FilterTypeInt:
+		parsed, err = strconv.ParseInt(value, 10, 64)
+		code = "int"
+	case FilterTypeFloat:
+		parsed, err = strconv.ParseFloat(value, 64)
+		code = "float"
+	case FilterTypeBool:
+		parsed, err = strconv.ParseBool(value)
+		code = "bool"
+	case FilterTypeTime:
+		parsed, err = parseFilterTime(value)
+		code = "datetime"
+	default:
+		parsed = value
+	}
+
+	if err != nil {
+		cause := FieldError(field.Name, code)
+		return nil, &cause
+	}
+
+	return parsed, nil
+}
+
+func (field *FilterField) allows(operator string) bool {
+	operators := field.Operators
+	if operators == nil {
+		operators = defaultFilterOperators[field.Type]
+	}
+
+	for _, allowed := range operators {
+		if allowed == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isFilterOperator(operator string) bool {
+	switch operator {
+	case FilterEq, FilterNe, FilterGt, FilterLt, FilterIn, FilterLike, FilterIsNull:
+		return true
+	}
+
+	return false
+}
+
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse("2006-01-02", value)
+}
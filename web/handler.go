@@ -0,0 +1,219 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+type ginContextKey struct{}
+
+type handleConfig struct {
+	status   int
+	registry *ErrorRegistry
+	bindOpts []BindOpt
+}
+
+type HandleOpt = func(*handleConfig)
+
+func HandleStatus(status int) HandleOpt {
+	return func(config *handleConfig) {
+		config.status = status
+	}
+}
+
+func HandleErrorRegistry(registry *ErrorRegistry) HandleOpt {
+	return func(config *handleConfig) {
+		config.registry = registry
+	}
+}
+
+func HandleBindOptions(opts ...BindOpt) HandleOpt {
+	return func(config *handleConfig) {
+		config.bindOpts = append(config.bindOpts, opts...)
+	}
+}
+
+func Handle[Req any, Resp any](handler func(ctx context.Context, req Req) (Resp, error), opts ...HandleOpt) gin.HandlerFunc {
+	config := &handleConfig{
+		status:   http.StatusOK,
+		registry: DefaultErrorRegistry,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(c *gin.Context) {
+		var req Req
+		if ok, causes := bindRequest(c, &req, config.bindOpts); !ok {
+			ValidationError(c, causes...)
+			return
+		}
+
+		ctx := context.WithValue(c.Request.Context(), ginContextKey{}, c)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			config.registry.HandleError(c, err, "Error while handling request")
+			return
+		}
+
+		if isNilValue(resp) {
+			c.Status(http.StatusNoContent)
+			return
+		}
+
+		Render(c, config.status, resp)
+	}
+}
+
+func GinContext(ctx context.Context) *gin.Context {
+	c, _ := ctx.Value(ginContextKey{}).(*gin.Context)
+	return c
+}
+
+func bindRequest(c *gin.Context, req interface{}, bindOpts []BindOpt) (bool, []Cause) {
+	var bodyFields map[string]bool
+	if hasRequestBody(c) {
+		body, cause := decodeJSONBody(c, req, newBindConfig(bindOpts))
+		if cause != nil {
+			return false, []Cause{*cause}
+		}
+
+		bodyFields = jsonObjectKeys(body)
+	}
+
+	v := reflect.ValueOf(req).Elem()
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return true, nil
+	}
+
+	if causes := bindQueryStruct(c.Request.URL.Query(), v, bodyFields); causes != nil {
+		return false, causes
+	}
+
+	if causes := bindPathStruct(c, v); causes != nil {
+		return false, causes
+	}
+
+	return ValidateStructFor(c, v.Addr().Interface())
+}
+
+func bindQueryStruct(query url.Values, v reflect.Value, bodyFields map[string]bool) []Cause {
+	var causes []Cause
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.SplitN(field.Tag.Get("form"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				causes = append(causes, bindQueryStruct(query, v.Field(i), bodyFields)...)
+			}
+			continue
+		}
+
+		if bodyFields[jsonFieldKey(field)] {
+			continue
+		}
+
+		values := query[name]
+		if len(values) == 0 {
+			continue
+		}
+
+		if code, ok := setQueryValue(v.Field(i), values); !ok {
+			causes = append(causes, FieldError(name, code))
+		}
+	}
+
+	return causes
+}
+
+func setQueryValue(v reflect.Value, values []string) (string, bool) {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, raw := range values {
+			if code, ok := setPathValue(slice.Index(i), raw); !ok {
+				return code, false
+			}
+		}
+
+		v.Set(slice)
+		return "", true
+	}
+
+	return setPathValue(v, values[0])
+}
+
+func jsonObjectKeys(body []byte) map[string]bool {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil
+	}
+
+	keys := make(map[string]bool, len(object))
+	for key := range object {
+		keys[strings.ToLower(key)] = true
+	}
+
+	return keys
+}
+
+func jsonFieldKey(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = field.Name
+	}
+
+	return strings.ToLower(name)
+}
+
+func hasRequestBody(c *gin.Context) bool {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return false
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return c.Request.ContentLength > 0
+	}
+
+	return c.Request.ContentLength != 0
+}
+
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+
+	return false
+}
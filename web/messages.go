@@ -0,0 +1,202 @@
+package web
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	esTranslations "github.com/go-playground/validator/v10/translations/es"
+	frTranslations "github.com/go-playground/validator/v10/translations/fr"
+	itTranslations "github.com/go-playground/validator/v10/translations/it"
+	nlTranslations "github.com/go-playground/validator/v10/translations/nl"
+	ptTranslations "github.com/go-playground/validator/v10/translations/pt"
+	ruTranslations "github.com/go-playground/validator/v10/translations/ru"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type registerTranslationsFunc = func(v *validator.Validate, trans ut.Translator) error
+
+type supportedLocale struct {
+	translator locales.Translator
+	register   registerTranslationsFunc
+}
+
+var supportedLocales = map[string]supportedLocale{
+	"en": {en.New(), enTranslations.RegisterDefaultTranslations},
+	"es": {es.New(), esTranslations.RegisterDefaultTranslations},
+	"fr": {fr.New(), frTranslations.RegisterDefaultTranslations},
+	"it": {it.New(), itTranslations.RegisterDefaultTranslations},
+	"nl": {nl.New(), nlTranslations.RegisterDefaultTranslations},
+	"pt": {pt.New(), ptTranslations.RegisterDefaultTranslations},
+	"ru": {ru.New(), ruTranslations.RegisterDefaultTranslations},
+}
+
+var messageCatalogs sync.Map
+
+type MessageCatalog struct {
+	validator *validator.Validate
+	universal *ut.UniversalTranslator
+	fallback  ut.Translator
+}
+
+var DefaultMessageCatalog *MessageCatalog = mustMessageCatalog(NewMessageCatalog(DefaultValidator, "en"))
+
+func NewMessageCatalog(v *validator.Validate, fallbackLocale string, additionalLocales ...string) (*MessageCatalog, error) {
+	fallback, ok := supportedLocales[fallbackLocale]
+	if !ok {
+		fallbackLocale = "en"
+		fallback = supportedLocales[fallbackLocale]
+	}
+
+	localeNames := []string{fallbackLocale}
+	if len(additionalLocales) == 0 {
+		for name := range supportedLocales {
+			if name != fallbackLocale {
+				localeNames = append(localeNames, name)
+			}
+		}
+	} else {
+		localeNames = append(localeNames, additionalLocales...)
+	}
+
+	var translators []locales.Translator
+	for _, name := range localeNames {
+		if locale, ok := supportedLocales[name]; ok {
+			translators = append(translators, locale.translator)
+		}
+	}
+
+	universal := ut.New(fallback.translator, translators...)
+
+	for _, name := range localeNames {
+		locale, ok := supportedLocales[name]
+		if !ok {
+			continue
+		}
+
+		trans, _ := universal.GetTranslator(name)
+		if err := locale.register(v, trans); err != nil {
+			return nil, err
+		}
+	}
+
+	fallbackTranslator, _ := universal.GetTranslator(fallbackLocale)
+
+	catalog := &MessageCatalog{
+		validator: v,
+		universal: universal,
+		fallback:  fallbackTranslator,
+	}
+
+	messageCatalogs.Store(v, catalog)
+	return catalog, nil
+}
+
+func (catalog *MessageCatalog) Override(locale, tag, message string) error {
+	trans, found := catalog.universal.GetTranslator(locale)
+	if !found {
+		return fmt.Errorf("locale '%s' is not registered in message catalog", locale)
+	}
+
+	return catalog.validator.RegisterTranslation(
+		tag,
+		trans,
+		func(trans ut.Translator) error {
+			return trans.Add(tag, message, true)
+		},
+		func(trans ut.Translator, fieldError validator.FieldError) string {
+			translated, err := trans.T(tag, fieldError.Field(), fieldError.Param())
+			if err != nil {
+				return fieldError.Error()
+			}
+
+			return translated
+		},
+	)
+}
+
+func (catalog *MessageCatalog) Translator(acceptLanguage string) ut.Translator {
+	trans, _ := catalog.universal.FindTranslator(parseAcceptLanguage(acceptLanguage)...)
+	return trans
+}
+
+func ValidateStructFor(c *gin.Context, s interface{}) (bool, []Cause) {
+	return validateStruct(DefaultValidator, DefaultMessageCatalog.Translator(c.GetHeader("Accept-Language")), s)
+}
+
+func ValidateVarFor(c *gin.Context, name string, value interface{}, tag string) (bool, []Cause) {
+	return validateVar(DefaultValidator, DefaultMessageCatalog.Translator(c.GetHeader("Accept-Language")), name, value, tag)
+}
+
+func defaultTranslator(v *validator.Validate) ut.Translator {
+	catalog, ok := messageCatalogs.Load(v)
+	if !ok {
+		return nil
+	}
+
+	return catalog.(*MessageCatalog).fallback
+}
+
+func parseAcceptLanguage(header string) []string {
+	type weightedLocale struct {
+		locale string
+		weight float64
+	}
+
+	var weighted []weightedLocale
+	for _, entry := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ";")
+		locale := strings.TrimSpace(parts[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+
+		weighted = append(weighted, weightedLocale{locale: locale, weight: weight})
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].weight > weighted[j].weight
+	})
+
+	var result []string
+	for _, w := range weighted {
+		locale := strings.ReplaceAll(w.locale, "-", "_")
+		result = append(result, locale)
+
+		if i := strings.Index(locale, "_"); i > 0 {
+			result = append(result, strings.ToLower(locale[:i]))
+		}
+	}
+
+	return result
+}
+
+func mustMessageCatalog(catalog *MessageCatalog, err error) *MessageCatalog {
+	if err != nil {
+		panic(err)
+	}
+
+	return catalog
+}
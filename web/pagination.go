@@ -1,5 +1,14 @@
 package web
 
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"strings"
+)
+
 type PaginationOptions struct {
 	PageNumber int
 	PageSize   int
@@ -40,6 +49,20 @@ func (opts PaginationOptions) NumberOfPages(recordsCount int64) int {
 	return pagesCount
 }
 
+func (opts PaginationOptions) Page(recordsCount int64) Page {
+	return Page{
+		PageNumber: opts.PageNumber,
+		PageSize:   opts.RealPageSize(recordsCount),
+		TotalPages: opts.NumberOfPages(recordsCount),
+	}
+}
+
+func (opts PaginationOptions) Scope() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Offset(opts.Offset()).Limit(opts.Limit())
+	}
+}
+
 type Page struct {
 	PageNumber int `json:"pageNumber"`
 	PageSize   int `json:"pageSize"`
@@ -50,3 +73,147 @@ type SortingOptions struct {
 	Field   int
 	Reverse bool
 }
+
+type SortableField struct {
+	Name   string
+	Column string
+}
+
+type SortableFields []SortableField
+
+func ParsePaginationOptions(c *gin.Context, defaultPageSize, maxPageSize int) (PaginationOptions, []Cause) {
+	opts := PaginationOptions{
+		PageNumber: 0,
+		PageSize:   defaultPageSize,
+	}
+	var causes []Cause
+
+	if pageValue := c.Query("page"); pageValue != "" {
+		page, err := strconv.Atoi(pageValue)
+		if err != nil {
+			causes = append(causes, FieldError("page", "int"))
+		} else if page < 0 {
+			causes = append(causes, FieldError("page", "min"))
+		} else {
+			opts.PageNumber = page
+		}
+	}
+
+	if sizeValue := c.Query("size"); sizeValue != "" {
+		size, err := strconv.Atoi(sizeValue)
+		if err != nil {
+			causes = append(causes, FieldError("size", "int"))
+		} else if size < 1 {
+			causes = append(causes, FieldError("size", "min"))
+		} else if size > maxPageSize {
+			causes = append(causes, FieldError("size", "max"))
+		} else {
+			opts.PageSize = size
+		}
+	}
+
+	return opts, causes
+}
+
+func ParseSortingOptions(c *gin.Context, fields SortableFields, defaults ...SortingOptions) ([]SortingOptions, []Cause) {
+	sortValue := c.Query("sort")
+	if sortValue == "" {
+		return defaults, nil
+	}
+
+	var sorting []SortingOptions
+	seen := make(map[int]struct{})
+
+	for _, entry := range strings.Split(sortValue, ",") {
+		entry = strings.TrimSpace(entry)
+		reverse := false
+
+		if strings.HasPrefix(entry, "-") {
+			reverse = true
+			entry = entry[1:]
+		} else if strings.HasPrefix(entry, "+") {
+			entry = entry[1:]
+		}
+
+		field := fields.index(entry)
+		if field < 0 {
+			return nil, []Cause{FieldErrorMessage("sort", "oneof", fmt.Sprintf("Unknown sort field '%s'", entry))}
+		}
+
+		if _, ok := seen[field]; ok {
+			return nil, []Cause{FieldErrorMessage("sort", "unique", fmt.Sprintf("Duplicate sort field '%s'", entry))}
+		}
+
+		seen[field] = struct{}{}
+		sorting = append(sorting, SortingOptions{Field: field, Reverse: reverse})
+	}
+
+	return sorting, nil
+}
+
+func SortingScope(sorting []SortingOptions, fields SortableFields) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, opts := range sorting {
+			if opts.Field < 0 || opts.Field >= len(fields) {
+				continue
+			}
+
+			db = db.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: fields[opts.Field].Column},
+				Desc:   opts.Reverse,
+			})
+		}
+
+		return db
+	}
+}
+
+func SetPaginationLinks(c *gin.Context, opts PaginationOptions, recordsCount int64) {
+	totalPages := opts.NumberOfPages(recordsCount)
+	var links []string
+
+	addLink := func(page int, rel string) {
+		u := *c.Request.URL
+		query := u.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("size", strconv.Itoa(opts.PageSize))
+		u.RawQuery = query.Encode()
+
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel))
+	}
+
+	if totalPages > 0 {
+		addLink(0, "first")
+	}
+
+	if opts.PageNumber > 0 && totalPages > 0 {
+		prev := opts.PageNumber - 1
+		if prev > totalPages-1 {
+			prev = totalPages - 1
+		}
+
+		addLink(prev, "prev")
+	}
+
+	if opts.PageNumber+1 < totalPages {
+		addLink(opts.PageNumber+1, "next")
+	}
+
+	if totalPages > 0 {
+		addLink(totalPages-1, "last")
+	}
+
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+func (fields SortableFields) index(name string) int {
+	for i, field := range fields {
+		if field.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,638 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
+	"io"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+type PatchResult struct {
+	ChangedFields []string
+	ChangedPaths  []string
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type patchError struct {
+	path    string
+	code    string
+	message string
+}
+
+func (err *patchError) Error() string {
+	return err.message
+}
+
+func BindPatch(c *gin.Context, target interface{}) (*PatchResult, []Cause, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType == MIMEJSONPatch {
+		return BindJSONPatch(c, target)
+	}
+
+	return BindMergePatch(c, target)
+}
+
+func BindMergePatch(c *gin.Context, target interface{}) (*PatchResult, []Cause, error) {
+	patch, cause := readPatchBody(c)
+	if cause != nil {
+		return nil, []Cause{*cause}, nil
+	}
+
+	return applyPatch(target, translatorFor(c), func(document interface{}) (interface{}, error) {
+		return mergePatch(document, patch)
+	})
+}
+
+func BindJSONPatch(c *gin.Context, target interface{}) (*PatchResult, []Cause, error) {
+	patch, cause := readPatchBody(c)
+	if cause != nil {
+		return nil, []Cause{*cause}, nil
+	}
+
+	return applyPatch(target, translatorFor(c), func(document interface{}) (interface{}, error) {
+		return jsonPatch(document, patch)
+	})
+}
+
+func ApplyMergePatch(target interface{}, patch []byte) (*PatchResult, []Cause, error) {
+	return applyPatch(target, defaultTranslator(DefaultValidator), func(document interface{}) (interface{}, error) {
+		return mergePatch(document, patch)
+	})
+}
+
+func ApplyJSONPatch(target interface{}, patch []byte) (*PatchResult, []Cause, error) {
+	return applyPatch(target, defaultTranslator(DefaultValidator), func(document interface{}) (interface{}, error) {
+		return jsonPatch(document, patch)
+	})
+}
+
+func readPatchBody(c *gin.Context) ([]byte, *Cause) {
+	if c.Request.Body == nil {
+		return nil, &Cause{Field: "body", Code: "required", Message: "Request body is empty"}
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(bytes.TrimSpace(patch)) == 0 {
+		return nil, &Cause{Field: "body", Code: "required", Message: "Request body is empty"}
+	}
+
+	return patch, nil
+}
+
+func translatorFor(c *gin.Context) ut.Translator {
+	return DefaultMessageCatalog.Translator(c.GetHeader("Accept-Language"))
+}
+
+func applyPatch(
+	target interface{},
+	trans ut.Translator,
+	apply func(document interface{}) (interface{}, error),
+) (*PatchResult, []Cause, error) {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return nil, nil, errors.New("patch target must be a non-nil pointer to struct")
+	}
+
+	original, err := toJSONDocument(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patched, err := apply(deepCopyDocument(original))
+	if err != nil {
+		var pe *patchError
+		if errors.As(err, &pe) {
+			return nil, []Cause{FieldErrorMessage(pe.path, pe.code, pe.message)}, nil
+		}
+
+		return nil, nil, err
+	}
+
+	data, err := json.Marshal(patched)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := reflect.New(targetValue.Elem().Type())
+	result.Elem().Set(targetValue.Elem())
+	clearJSONFields(result.Elem())
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(result.Interface()); err != nil {
+		return nil, []Cause{*jsonDecodeCause(err, data)}, nil
+	}
+
+	if ok, causes := validateStruct(DefaultValidator, trans, result.Interface()); !ok {
+		return nil, causes, nil
+	}
+
+	updated, err := toJSONDocument(result.Interface())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changedPaths []string
+	diffDocuments("", original, updated, &changedPaths)
+	sort.Strings(changedPaths)
+
+	targetValue.Elem().Set(result.Elem())
+
+	return &PatchResult{
+		ChangedFields: changedStructFields(targetValue.Elem().Type(), changedPaths),
+		ChangedPaths:  changedPaths,
+	}, nil, nil
+}
+
+func mergePatch(document interface{}, patch []byte) (interface{}, error) {
+	var patchDocument interface{}
+	if err := unmarshalDocument(patch, &patchDocument); err != nil {
+		return nil, &patchError{path: "body", code: "json", message: "Patch is not a valid JSON document"}
+	}
+
+	if _, ok := patchDocument.(map[string]interface{}); !ok {
+		return nil, &patchError{path: "body", code: "type", message: "Merge patch must be a JSON object"}
+	}
+
+	return mergeDocuments(document, patchDocument), nil
+}
+
+func mergeDocuments(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergeDocuments(targetObject[key], value)
+		}
+	}
+
+	return targetObject
+}
+
+func jsonPatch(document interface{}, patch []byte) (interface{}, error) {
+	var operations []PatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, &patchError{path: "body", code: "json", message: "Patch is not a valid JSON Patch document"}
+	}
+
+	for i, operation := range operations {
+		var err error
+		document, err = applyOperation(document, operation)
+		if err != nil {
+			var pe *patchError
+			if errors.As(err, &pe) {
+				pe.message = fmt.Sprintf("Operation %d (%s): %s", i, operation.Op, pe.message)
+			}
+
+			return nil, err
+		}
+	}
+
+	return document, nil
+}
+
+func applyOperation(document interface{}, operation PatchOperation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if len(operation.Value) == 0 {
+			return nil, &patchError{path: pointerField(operation.Path), code: "value", message: "value is missing"}
+		}
+
+		var value interface{}
+		if err := unmarshalDocument(operation.Value, &value); err != nil {
+			return nil, &patchError{path: pointerField(operation.Path), code: "value", message: "value is not valid JSON"}
+		}
+
+		switch operation.Op {
+		case "add":
+			return addValue(document, path, value, operation.Path)
+		case "replace":
+			if _, err := getValue(document, path, operation.Path); err != nil {
+				return nil, err
+			}
+
+			document, _, err = removeValue(document, path, operation.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			return addValue(document, path, value, operation.Path)
+		default:
+			current, err := getValue(document, path, operation.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !documentsEqual(current, value) {
+				return nil, &patchError{path: pointerField(operation.Path), code: "test", message: "test failed"}
+			}
+
+			return document, nil
+		}
+	case "remove":
+		document, _, err = removeValue(document, path, operation.Path)
+		return document, err
+	case "move", "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		if operation.Op == "move" {
+			if strings.HasPrefix(operation.Path+"/", operation.From+"/") && operation.Path != operation.From {
+				return nil, &patchError{path: pointerField(operation.From), code: "path", message: "cannot move a value into its own child"}
+			}
+
+			document, value, err = removeValue(document, from, operation.From)
+		} else {
+			value, err = getValue(document, from, operation.From)
+			value = deepCopyDocument(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(document, path, value, operation.Path)
+	}
+
+	return nil, &patchError{path: "op", code: "oneof", message: "unknown operation " + strconv.Quote(operation.Op)}
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, &patchError{path: "path", code: "pointer", message: "invalid JSON pointer " + strconv.Quote(pointer)}
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+func pointerField(pointer string) string {
+	tokens, err := parsePointer(pointer)
+	if err != nil || len(tokens) == 0 {
+		return "body"
+	}
+
+	return strings.Join(tokens, ".")
+}
+
+func getValue(document interface{}, path []string, pointer string) (interface{}, error) {
+	current := document
+	for _, token := range path {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, pathNotFound(pointer)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1, pointer)
+			if err != nil {
+				return nil, err
+			}
+			current = container[index]
+		default:
+			return nil, pathNotFound(pointer)
+		}
+	}
+
+	return current, nil
+}
+
+func addValue(document interface{}, path []string, value interface{}, pointer string) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(document, path[:len(path)-1], pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[token] = value
+		return document, nil
+	case []interface{}:
+		index := len(container)
+		if token != "-" {
+			index, err = arrayIndex(token, len(container), pointer)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		updated := append(container[:index:index], append([]interface{}{value}, container[index:]...)...)
+		return replaceContainer(document, path[:len(path)-1], updated, pointer)
+	}
+
+	return nil, pathNotFound(pointer)
+}
+
+func removeValue(document interface{}, path []string, pointer string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, document, nil
+	}
+
+	parent, err := getValue(document, path[:len(path)-1], pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		value, ok := container[token]
+		if !ok {
+			return nil, nil, pathNotFound(pointer)
+		}
+
+		delete(container, token)
+		return document, value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(container)-1, pointer)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value := container[index]
+		updated := append(container[:index:index], container[index+1:]...)
+		document, err = replaceContainer(document, path[:len(path)-1], updated, pointer)
+		return document, value, err
+	}
+
+	return nil, nil, pathNotFound(pointer)
+}
+
+func replaceContainer(document interface{}, path []string, container []interface{}, pointer string) (interface{}, error) {
+	if len(path) == 0 {
+		return container, nil
+	}
+
+	parent, err := getValue(document, path[:len(path)-1], pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		p[token] = container
+	case []interface{}:
+		index, err := arrayIndex(token, len(p)-1, pointer)
+		if err != nil {
+			return nil, err
+		}
+		p[index] = container
+	}
+
+	return document, nil
+}
+
+func arrayIndex(token string, max int, pointer string) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max || (len(token) > 1 && token[0] == '0') {
+		return 0, pathNotFound(pointer)
+	}
+
+	return index, nil
+}
+
+func pathNotFound(pointer string) error {
+	return &patchError{path: pointerField(pointer), code: "path", message: "path " + strconv.Quote(pointer) + " does not exist"}
+}
+
+func toJSONDocument(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	if err := unmarshalDocument(data, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func unmarshalDocument(data []byte, document *interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(document)
+}
+
+func deepCopyDocument(document interface{}) interface{} {
+	switch value := document.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = deepCopyDocument(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = deepCopyDocument(v)
+		}
+		return result
+	}
+
+	return document
+}
+
+func documentsEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		af, errA := av.Float64()
+		bf, errB := bv.Float64()
+		return errA == nil && errB == nil && af == bf
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for k, v := range av {
+			other, ok := bv[k]
+			if !ok || !documentsEqual(v, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !documentsEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return a == b
+}
+
+func diffDocuments(prefix string, before, after interface{}, changed *[]string) {
+	beforeObject, beforeIsObject := before.(map[string]interface{})
+	afterObject, afterIsObject := after.(map[string]interface{})
+
+	if !beforeIsObject || !afterIsObject {
+		if !documentsEqual(before, after) {
+			*changed = append(*changed, prefix)
+		}
+		return
+	}
+
+	keys := make(map[string]struct{})
+	for k := range beforeObject {
+		keys[k] = struct{}{}
+	}
+	for k := range afterObject {
+		keys[k] = struct{}{}
+	}
+
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		diffDocuments(path, beforeObject[k], afterObject[k], changed)
+	}
+}
+
+func changedStructFields(t reflect.Type, changedPaths []string) []string {
+	names := jsonFieldNames(t)
+
+	var fields []string
+	seen := make(map[string]struct{})
+	for _, path := range changedPaths {
+		key := strings.SplitN(path, ".", 2)[0]
+		name, ok := names[key]
+		if !ok {
+			continue
+		}
+
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
+}
+
+func jsonFieldNames(t reflect.Type) map[string]string {
+	names := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFieldNames(embedded) {
+					if _, ok := names[k]; !ok {
+						names[k] = v
+					}
+				}
+				continue
+			}
+		}
+
+		if tag == "" {
+			tag = field.Name
+		}
+
+		names[tag] = field.Name
+	}
+
+	return names
+}
+
+func clearJSONFields(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			clearJSONFields(v.Field(i))
+			continue
+		}
+
+		v.Field(i).Set(reflect.Zero(field.Type))
+	}
+}
@@ -0,0 +1,215 @@
+package web
+
+import (
+	"encoding"
+	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+var timeType = reflect.TypeOf(time.Time{})
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func BindPathParams(c *gin.Context, val interface{}) (bool, []Cause) {
+	if causes := bindPathValues(c, val); causes != nil {
+		return false, causes
+	}
+
+	ok, causes := ValidateStructFor(c, val)
+	if !ok {
+		names := pathParamNames(reflect.TypeOf(val))
+		for i := range causes {
+			if name, found := names[causes[i].Field]; found {
+				causes[i].Field = name
+			}
+		}
+	}
+
+	return ok, causes
+}
+
+func RequirePathParams(c *gin.Context, val interface{}) bool {
+	ok, causes := BindPathParams(c, val)
+	if !ok {
+		ValidationError(c, causes...)
+		c.Abort()
+	}
+
+	return ok
+}
+
+func PathUUID(c *gin.Context, name string) (uuid.UUID, []Cause) {
+	value, err := uuid.FromString(c.Param(name))
+	if err != nil {
+		return uuid.Nil, []Cause{FieldError(name, "uuid")}
+	}
+
+	return value, nil
+}
+
+func PathInt64(c *gin.Context, name string) (int64, []Cause) {
+	value, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, []Cause{FieldError(name, "int")}
+	}
+
+	return value, nil
+}
+
+func bindPathValues(c *gin.Context, val interface{}) []Cause {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	return bindPathStruct(c, v.Elem())
+}
+
+func bindPathStruct(c *gin.Context, v reflect.Value) []Cause {
+	var causes []Cause
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.SplitN(field.Tag.Get("uri"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				causes = append(causes, bindPathStruct(c, v.Field(i))...)
+			}
+			continue
+		}
+
+		raw, ok := c.Params.Get(name)
+		if !ok || raw == "" {
+			continue
+		}
+
+		if code, ok := setPathValue(v.Field(i), raw); !ok {
+			causes = append(causes, FieldError(name, code))
+		}
+	}
+
+	return causes
+}
+
+func setPathValue(v reflect.Value, raw string) (string, bool) {
+	if v.Kind() == reflect.Ptr {
+		value := reflect.New(v.Type().Elem())
+		if code, ok := setPathValue(value.Elem(), raw); !ok {
+			return code, false
+		}
+
+		v.Set(value)
+		return "", true
+	}
+
+	switch v.Type() {
+	case uuidType:
+		value, err := uuid.FromString(raw)
+		if err != nil {
+			return "uuid", false
+		}
+
+		v.Set(reflect.ValueOf(value))
+		return "", true
+	case timeType:
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return "datetime", false
+		}
+
+		v.Set(reflect.ValueOf(value.UTC()))
+		return "", true
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return "invalid", false
+		}
+
+		return "", true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return "int", false
+		}
+
+		v.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return "uint", false
+		}
+
+		v.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return "float", false
+		}
+
+		v.SetFloat(value)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "bool", false
+		}
+
+		v.SetBool(value)
+	default:
+		return "invalid", false
+	}
+
+	return "", true
+}
+
+func pathParamNames(t reflect.Type) map[string]string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	names := make(map[string]string)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.SplitN(field.Tag.Get("uri"), ",", 2)[0]
+		if name == "" || name == "-" {
+			if field.Anonymous {
+				for k, v := range pathParamNames(field.Type) {
+					names[k] = v
+				}
+			}
+			continue
+		}
+
+		validatorName := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if validatorName == "" || validatorName == "-" {
+			validatorName = field.Name
+		}
+
+		names[validatorName] = name
+	}
+
+	return names
+}
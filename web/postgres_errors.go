@@ -0,0 +1,62 @@
+package web
+
+import (
+	"errors"
+	"github.com/jackc/pgconn"
+	"github.com/mkorman9/go-commons/postgres"
+	"net/http"
+)
+
+func RegisterPostgresErrors(registry *ErrorRegistry, constraintFields map[string]string) {
+	registry.RegisterMapper(func(err error) (*ErrorMapping, bool) {
+		code := postgres.ErrorCode(err)
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			code = postgres.ErrorCode(pgErr)
+		}
+
+		switch code {
+		case postgres.ErrRecordNotFound:
+			return &ErrorMapping{Status: http.StatusNotFound, Message: "Not found"}, true
+		case postgres.ErrUniqueViolation:
+			return &ErrorMapping{
+				Status:  http.StatusConflict,
+				Message: "Conflict",
+				Causes:  postgresCauses(pgErr, constraintFields, "unique"),
+			}, true
+		case postgres.ErrNotNullViolation:
+			return &ErrorMapping{
+				Status:  http.StatusBadRequest,
+				Message: "Validation Error",
+				Causes:  postgresCauses(pgErr, constraintFields, "required"),
+			}, true
+		case postgres.ErrInvalidText:
+			return &ErrorMapping{
+				Status:  http.StatusBadRequest,
+				Message: "Validation Error",
+				Causes:  postgresCauses(pgErr, constraintFields, "invalid"),
+			}, true
+		}
+
+		return nil, false
+	})
+}
+
+func postgresCauses(pgErr *pgconn.PgError, constraintFields map[string]string, code string) []Cause {
+	if pgErr == nil {
+		return nil
+	}
+
+	for _, name := range []string{pgErr.ConstraintName, pgErr.ColumnName} {
+		if name == "" {
+			continue
+		}
+
+		if field, ok := constraintFields[name]; ok {
+			return []Cause{FieldError(field, code)}
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,12 @@
 package web
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
+	uuid "github.com/satori/go.uuid"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -9,14 +14,136 @@ type QueryParamValue struct {
 	value interface{}
 }
 
-type QueryParamsParser func(string) interface{}
+type QueryParamParseError struct {
+	Code string
+}
+
+func (err *QueryParamParseError) Error() string {
+	return "query param parsing error: " + err.Code
+}
+
+type QueryParamsParser func(string) (interface{}, error)
 type QueryParamsParsingRules = map[string]QueryParamsParser
 type QueryParamsMap = map[string]QueryParamValue
 
+var IntParser QueryParamsParser = typedParser("int", func(value string) (interface{}, error) {
+	return strconv.Atoi(value)
+})
+
+var Int64Parser QueryParamsParser = typedParser("int64", func(value string) (interface{}, error) {
+	return strconv.ParseInt(value, 10, 64)
+})
+
+var FloatParser QueryParamsParser = typedParser("float", func(value string) (interface{}, error) {
+	return strconv.ParseFloat(value, 64)
+})
+
+var BoolParser QueryParamsParser = typedParser("bool", func(value string) (interface{}, error) {
+	return strconv.ParseBool(value)
+})
+
+var DurationParser QueryParamsParser = typedParser("duration", func(value string) (interface{}, error) {
+	return time.ParseDuration(value)
+})
+
+var TimeParser QueryParamsParser = typedParser("datetime", func(value string) (interface{}, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	t = t.UTC()
+	return &t, nil
+})
+
+var UnixTimeParser QueryParamsParser = typedParser("unix", func(value string) (interface{}, error) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	t := time.Unix(seconds, 0).UTC()
+	return &t, nil
+})
+
+var UUIDParser QueryParamsParser = typedParser("uuid", func(value string) (interface{}, error) {
+	return uuid.FromString(value)
+})
+
+var StringParser QueryParamsParser = func(value string) (interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+func EnumParser(allowedValues ...string) QueryParamsParser {
+	return func(value string) (interface{}, error) {
+		if value == "" {
+			return nil, nil
+		}
+
+		for _, allowedValue := range allowedValues {
+			if value == allowedValue {
+				return value, nil
+			}
+		}
+
+		return nil, &QueryParamParseError{Code: "oneof"}
+	}
+}
+
+func ListParser(itemParser QueryParamsParser) QueryParamsParser {
+	return func(value string) (interface{}, error) {
+		if value == "" {
+			return nil, nil
+		}
+
+		var items []interface{}
+		for _, item := range strings.Split(value, ",") {
+			parsedItem, err := itemParser(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+
+			if parsedItem != nil {
+				items = append(items, parsedItem)
+			}
+		}
+
+		return items, nil
+	}
+}
+
+func Required(parser QueryParamsParser) QueryParamsParser {
+	return func(value string) (interface{}, error) {
+		if value == "" {
+			return nil, &QueryParamParseError{Code: "required"}
+		}
+
+		return parser(value)
+	}
+}
+
+func WithDefault(parser QueryParamsParser, defaultValue interface{}) QueryParamsParser {
+	return func(value string) (interface{}, error) {
+		if value == "" {
+			return defaultValue, nil
+		}
+
+		return parser(value)
+	}
+}
+
 func (queryParamValue QueryParamValue) Value() interface{} {
 	return queryParamValue.value
 }
 
+func (queryParamValue QueryParamValue) IsSet() bool {
+	return queryParamValue.value != nil
+}
+
 func (queryParamValue QueryParamValue) String() string {
 	s, ok := queryParamValue.value.(string)
 	if ok {
@@ -44,6 +171,15 @@ func (queryParamValue QueryParamValue) Int64() int64 {
 	return 0
 }
 
+func (queryParamValue QueryParamValue) Float() float64 {
+	f, ok := queryParamValue.value.(float64)
+	if ok {
+		return f
+	}
+
+	return 0
+}
+
 func (queryParamValue QueryParamValue) Bool() bool {
 	b, ok := queryParamValue.value.(bool)
 	if ok {
@@ -62,14 +198,76 @@ func (queryParamValue QueryParamValue) Time() *time.Time {
 	return nil
 }
 
-func ParseQueryParams(c *gin.Context, rules QueryParamsParsingRules) QueryParamsMap {
+func (queryParamValue QueryParamValue) Duration() time.Duration {
+	d, ok := queryParamValue.value.(time.Duration)
+	if ok {
+		return d
+	}
+
+	return 0
+}
+
+func (queryParamValue QueryParamValue) UUID() uuid.UUID {
+	u, ok := queryParamValue.value.(uuid.UUID)
+	if ok {
+		return u
+	}
+
+	return uuid.Nil
+}
+
+func (queryParamValue QueryParamValue) List() []interface{} {
+	l, ok := queryParamValue.value.([]interface{})
+	if ok {
+		return l
+	}
+
+	return nil
+}
+
+func ParseQueryParams(c *gin.Context, rules QueryParamsParsingRules) (QueryParamsMap, []Cause) {
 	var result = make(QueryParamsMap)
+	var causes []Cause
+
+	params := make([]string, 0, len(rules))
+	for param := range rules {
+		params = append(params, param)
+	}
+	sort.Strings(params)
 
-	for param, parser := range rules {
+	for _, param := range params {
+		parser := rules[param]
 		value := c.Query(param)
-		parsedValue := parser(value)
+
+		parsedValue, err := parser(value)
+		if err != nil {
+			var parseError *QueryParamParseError
+			if errors.As(err, &parseError) {
+				causes = append(causes, FieldError(param, parseError.Code))
+			} else {
+				causes = append(causes, FieldErrorMessage(param, "invalid", err.Error()))
+			}
+
+			continue
+		}
+
 		result[param] = QueryParamValue{parsedValue}
 	}
 
-	return result
+	return result, causes
+}
+
+func typedParser(code string, parse func(string) (interface{}, error)) QueryParamsParser {
+	return func(value string) (interface{}, error) {
+		if value == "" {
+			return nil, nil
+		}
+
+		parsedValue, err := parse(value)
+		if err != nil {
+			return nil, &QueryParamParseError{Code: code}
+		}
+
+		return parsedValue, nil
+	}
 }
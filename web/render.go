@@ -0,0 +1,346 @@
+package web
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MIMEJSON     = "application/json"
+	MIMEMsgPack  = "application/msgpack"
+	MIMEProtobuf = "application/x-protobuf"
+	MIMECSV      = "text/csv"
+)
+
+var ErrUnsupportedValue = errors.New("value cannot be encoded in this format")
+
+type Encoder interface {
+	ContentType() string
+	Encode(w io.Writer, value interface{}) error
+}
+
+type encoderFunc struct {
+	contentType string
+	encode      func(w io.Writer, value interface{}) error
+}
+
+func (encoder *encoderFunc) ContentType() string {
+	return encoder.contentType
+}
+
+func (encoder *encoderFunc) Encode(w io.Writer, value interface{}) error {
+	return encoder.encode(w, value)
+}
+
+func EncoderFunc(contentType string, encode func(w io.Writer, value interface{}) error) Encoder {
+	return &encoderFunc{contentType: contentType, encode: encode}
+}
+
+var JSONEncoder = EncoderFunc("application/json; charset=utf-8", func(w io.Writer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+})
+
+var MsgPackEncoder = EncoderFunc("application/msgpack", func(w io.Writer, value interface{}) error {
+	var handle codec.MsgpackHandle
+	handle.WriteExt = true
+	return codec.NewEncoder(w, &handle).Encode(value)
+})
+
+var ProtobufEncoder = EncoderFunc("application/x-protobuf", func(w io.Writer, value interface{}) error {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return ErrUnsupportedValue
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+})
+
+var CSVEncoder = EncoderFunc("text/csv; charset=utf-8", func(w io.Writer, value interface{}) error {
+	records, err := csvRecords(value)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+
+	return writer.Error()
+})
+
+var encoders = map[string]Encoder{
+	MIMEJSON:                JSONEncoder,
+	MIMEMsgPack:             MsgPackEncoder,
+	"application/x-msgpack": MsgPackEncoder,
+	MIMEProtobuf:            ProtobufEncoder,
+	"application/protobuf":  ProtobufEncoder,
+	MIMECSV:                 CSVEncoder,
+}
+var encodersOrder = []string{MIMEJSON, MIMEMsgPack, "application/x-msgpack", MIMEProtobuf, "application/protobuf", MIMECSV}
+var encodersMutex sync.RWMutex
+
+func RegisterEncoder(mimeType string, encoder Encoder) {
+	encodersMutex.Lock()
+	defer encodersMutex.Unlock()
+
+	if _, ok := encoders[mimeType]; !ok {
+		encodersOrder = append(encodersOrder, mimeType)
+	}
+
+	encoders[mimeType] = encoder
+}
+
+func Render(c *gin.Context, status int, value interface{}) {
+	render(c, status, value, NegotiateFormat(c), "")
+}
+
+func NegotiateFormat(c *gin.Context) string {
+	encodersMutex.RLock()
+	defer encodersMutex.RUnlock()
+
+	for _, accepted := range parseAccept(c.GetHeader("Accept")) {
+		if _, ok := encoders[accepted]; ok {
+			return accepted
+		}
+
+		if accepted == "*/*" {
+			return MIMEJSON
+		}
+
+		if strings.HasSuffix(accepted, "/*") {
+			for _, mimeType := range encodersOrder {
+				if strings.HasPrefix(mimeType, strings.TrimSuffix(accepted, "*")) {
+					return mimeType
+				}
+			}
+		}
+	}
+
+	return MIMEJSON
+}
+
+func render(c *gin.Context, status int, value interface{}, mimeType, contentType string) {
+	encodersMutex.RLock()
+	encoder, ok := encoders[mimeType]
+	encodersMutex.RUnlock()
+
+	if !ok {
+		encoder = JSONEncoder
+	}
+
+	var buffer bytes.Buffer
+	err := encoder.Encode(&buffer, value)
+	if errors.Is(err, ErrUnsupportedValue) && encoder != JSONEncoder {
+		encoder = JSONEncoder
+		buffer.Reset()
+		err = encoder.Encode(&buffer, value)
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("Error while encoding response as %s", mimeType)
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	if contentType == "" || encoder != JSONEncoder {
+		contentType = encoder.ContentType()
+	}
+
+	c.Writer.Header().Add("Vary", "Accept")
+	c.Data(status, contentType, buffer.Bytes())
+}
+
+func parseAccept(header string) []string {
+	type weightedMediaType struct {
+		mediaType string
+		weight    float64
+	}
+
+	var weighted []weightedMediaType
+	for _, entry := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ";")
+		mediaType := strings.ToLower(strings.TrimSpace(parts[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+
+		if weight <= 0 {
+			continue
+		}
+
+		weighted = append(weighted, weightedMediaType{mediaType: mediaType, weight: weight})
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].weight > weighted[j].weight
+	})
+
+	var result []string
+	for _, w := range weighted {
+		result = append(result, w.mediaType)
+	}
+
+	return result
+}
+
+func csvRecords(value interface{}) ([][]string, error) {
+	if records, ok := value.([][]string); ok {
+		return records, nil
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, ErrUnsupportedValue
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := csvFields(v.Type())
+		return [][]string{csvHeader(fields), csvRow(v, fields)}, nil
+	case reflect.Slice, reflect.Array:
+		elemType := v.Type().Elem()
+		for elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() != reflect.Struct {
+			return nil, ErrUnsupportedValue
+		}
+
+		fields := csvFields(elemType)
+		records := [][]string{csvHeader(fields)}
+		for i := 0; i < v.Len(); i++ {
+			item := reflect.Indirect(v.Index(i))
+			if !item.IsValid() {
+				continue
+			}
+
+			records = append(records, csvRow(item, fields))
+		}
+
+		return records, nil
+	}
+
+	return nil, ErrUnsupportedValue
+}
+
+type csvField struct {
+	name  string
+	index int
+}
+
+func csvFields(t reflect.Type) []csvField {
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("csv")
+		if name == "" {
+			name = strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, csvField{name: name, index: i})
+	}
+
+	return fields
+}
+
+func csvHeader(fields []csvField) []string {
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.name
+	}
+
+	return header
+}
+
+func csvRow(v reflect.Value, fields []csvField) []string {
+	row := make([]string, len(fields))
+	for i, field := range fields {
+		row[i] = csvValue(v.Field(field.index))
+	}
+
+	return row
+}
+
+func csvValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case fmt.Stringer:
+		return value.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return ""
+		}
+		fallthrough
+	case reflect.Struct, reflect.Array:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+
+		return string(data)
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -3,10 +3,24 @@ package web
 import (
 	"github.com/rs/zerolog/log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	ResponseFormatGeneric = iota
+	ResponseFormatProblem = iota
+)
+
+const responseFormatContextKey = "web.responseFormat"
+const problemTypeContextKey = "web.problemType"
+
+const MIMEProblemJSON = "application/problem+json"
+
+var defaultResponseFormat = ResponseFormatGeneric
+var problemTypeBaseURI = ""
+
 type Cause struct {
 	Field   string `json:"field"`
 	Code    string `json:"code"`
@@ -19,6 +33,34 @@ type GenericResponse struct {
 	Causes  []Cause `json:"causes,omitempty"`
 }
 
+type ProblemDetails struct {
+	Type     string  `json:"type"`
+	Title    string  `json:"title"`
+	Status   int     `json:"status"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Causes   []Cause `json:"causes,omitempty"`
+}
+
+func SetResponseFormat(format int) {
+	defaultResponseFormat = format
+}
+
+func SetProblemTypeBaseURI(baseURI string) {
+	problemTypeBaseURI = baseURI
+}
+
+func ResponseFormat(format int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(responseFormatContextKey, format)
+		c.Next()
+	}
+}
+
+func ProblemType(c *gin.Context, typeURI string) {
+	c.Set(problemTypeContextKey, typeURI)
+}
+
 func SuccessResponse(c *gin.Context, message string) {
 	response(c, http.StatusOK, "success", message)
 }
@@ -46,5 +88,40 @@ func response(c *gin.Context, code int, status, message string, causes ...Cause)
 		ca = make([]Cause, 0)
 	}
 
-	c.JSON(code, &GenericResponse{Status: status, Message: message, Causes: ca})
+	if status == "error" && responseFormat(c) == ResponseFormatProblem {
+		render(c, code, problemDetails(c, code, message, causes), NegotiateFormat(c), MIMEProblemJSON)
+		return
+	}
+
+	Render(c, code, &GenericResponse{Status: status, Message: message, Causes: ca})
+}
+
+func responseFormat(c *gin.Context) int {
+	if value, ok := c.Get(responseFormatContextKey); ok {
+		if format, ok := value.(int); ok {
+			return format
+		}
+	}
+
+	return defaultResponseFormat
+}
+
+func problemDetails(c *gin.Context, code int, message string, causes []Cause) *ProblemDetails {
+	problemType := c.GetString(problemTypeContextKey)
+	if problemType == "" {
+		if problemTypeBaseURI != "" {
+			problemType = problemTypeBaseURI + strings.ReplaceAll(strings.ToLower(http.StatusText(code)), " ", "-")
+		} else {
+			problemType = "about:blank"
+		}
+	}
+
+	return &ProblemDetails{
+		Type:     problemType,
+		Title:    http.StatusText(code),
+		Status:   code,
+		Detail:   message,
+		Instance: c.GetHeader("X-Request-Id"),
+		Causes:   causes,
+	}
 }
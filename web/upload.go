@@ -0,0 +1,330 @@
+package web
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type UploadedFile struct {
+	FieldName   string
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	Location    string
+}
+
+type UploadResult struct {
+	Files  []*UploadedFile
+	Values map[string][]string
+}
+
+type UploadSink interface {
+	Create(file *UploadedFile) (io.WriteCloser, error)
+	Remove(file *UploadedFile) error
+}
+
+type diskSink struct {
+	directory string
+}
+
+func DiskSink(directory string) UploadSink {
+	return &diskSink{directory: directory}
+}
+
+func (sink *diskSink) Create(file *UploadedFile) (io.WriteCloser, error) {
+	f, err := os.CreateTemp(sink.directory, "upload-*")
+	if err != nil {
+		return nil, err
+	}
+
+	file.Location = f.Name()
+	return f, nil
+}
+
+func (sink *diskSink) Remove(file *UploadedFile) error {
+	if file.Location == "" {
+		return nil
+	}
+
+	return os.Remove(file.Location)
+}
+
+type writerSink struct {
+	writer io.Writer
+}
+
+func WriterSink(writer io.Writer) UploadSink {
+	return &writerSink{writer: writer}
+}
+
+func (sink *writerSink) Create(_ *UploadedFile) (io.WriteCloser, error) {
+	return nopWriteCloser{sink.writer}, nil
+}
+
+func (sink *writerSink) Remove(_ *UploadedFile) error {
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type uploadConfig struct {
+	maxFileSize  int64
+	maxTotalSize int64
+	maxFiles     int
+	maxValueSize int64
+	allowedTypes []string
+	sink         UploadSink
+	checksum     crypto.Hash
+}
+
+type UploadOpt = func(*uploadConfig)
+
+func UploadMaxFileSize(maxFileSize int64) UploadOpt {
+	return func(config *uploadConfig) {
+		config.maxFileSize = maxFileSize
+	}
+}
+
+func UploadMaxTotalSize(maxTotalSize int64) UploadOpt {
+	return func(config *uploadConfig) {
+		config.maxTotalSize = maxTotalSize
+	}
+}
+
+func UploadMaxFiles(maxFiles int) UploadOpt {
+	return func(config *uploadConfig) {
+		config.maxFiles = maxFiles
+	}
+}
+
+func UploadMaxValueSize(maxValueSize int64) UploadOpt {
+	return func(config *uploadConfig) {
+		config.maxValueSize = maxValueSize
+	}
+}
+
+func UploadAllowedTypes(allowedTypes ...string) UploadOpt {
+	return func(config *uploadConfig) {
+		config.allowedTypes = allowedTypes
+	}
+}
+
+func UploadSinkTo(sink UploadSink) UploadOpt {
+	return func(config *uploadConfig) {
+		config.sink = sink
+	}
+}
+
+func UploadChecksum(checksum crypto.Hash) UploadOpt {
+	return func(config *uploadConfig) {
+		config.checksum = checksum
+	}
+}
+
+func ReceiveUpload(c *gin.Context, opts ...UploadOpt) (*UploadResult, []Cause, error) {
+	config := &uploadConfig{
+		maxFileSize:  10 << 20,
+		maxTotalSize: 32 << 20,
+		maxValueSize: 1 << 20,
+		sink:         DiskSink(""),
+		checksum:     crypto.SHA256,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	if config.checksum != 0 && !config.checksum.Available() {
+		return nil, nil, errors.New("upload checksum algorithm is not available")
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, []Cause{FieldErrorMessage("body", "multipart", "Request body is not a multipart form")}, nil
+	}
+
+	result := &UploadResult{Values: make(map[string][]string)}
+	var totalSize int64
+
+	discard := func() {
+		for _, file := range result.Files {
+			_ = config.sink.Remove(file)
+		}
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			discard()
+			return nil, []Cause{FieldErrorMessage("body", "multipart", "Request body is not a valid multipart form")}, nil
+		}
+
+		fieldName := part.FormName()
+
+		if part.FileName() == "" {
+			value, err := io.ReadAll(io.LimitReader(part, config.maxValueSize+1))
+			part.Close()
+			if err != nil {
+				discard()
+				return nil, nil, err
+			}
+
+			if int64(len(value)) > config.maxValueSize {
+				discard()
+				return nil, []Cause{FieldErrorMessage(fieldName, "tooLarge", "Form value is too large")}, nil
+			}
+
+			totalSize += int64(len(value))
+			if config.maxTotalSize > 0 && totalSize > config.maxTotalSize {
+				discard()
+				return nil, []Cause{FieldErrorMessage("body", "tooLarge", "Request body is too large")}, nil
+			}
+
+			result.Values[fieldName] = append(result.Values[fieldName], string(value))
+			continue
+		}
+
+		if config.maxFiles > 0 && len(result.Files) >= config.maxFiles {
+			part.Close()
+			discard()
+			return nil, []Cause{FieldErrorMessage(fieldName, "tooMany", fmt.Sprintf("At most %d files are allowed", config.maxFiles))}, nil
+		}
+
+		file := &UploadedFile{
+			FieldName: fieldName,
+			FileName:  part.FileName(),
+		}
+
+		head := make([]byte, 512)
+		n, err := io.ReadFull(part, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			part.Close()
+			discard()
+			return nil, nil, err
+		}
+		head = head[:n]
+
+		file.ContentType = http.DetectContentType(head)
+		if !config.typeAllowed(file.ContentType) {
+			part.Close()
+			discard()
+			return nil, []Cause{FieldErrorMessage(fieldName, "contentType", "File type "+file.ContentType+" is not allowed")}, nil
+		}
+
+		result.Files = append(result.Files, file)
+
+		size, cause, err := config.store(file, io.MultiReader(bytes.NewReader(head), part), config.remaining(totalSize))
+		part.Close()
+		if err != nil || cause != nil {
+			discard()
+			if cause != nil {
+				return nil, []Cause{*cause}, nil
+			}
+
+			return nil, nil, err
+		}
+
+		totalSize += size
+	}
+
+	return result, nil, nil
+}
+
+func (config *uploadConfig) typeAllowed(contentType string) bool {
+	if len(config.allowedTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+
+	for _, allowed := range config.allowedTypes {
+		if allowed == mediaType || allowed == "*/*" {
+			return true
+		}
+
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (config *uploadConfig) remaining(totalSize int64) int64 {
+	if config.maxTotalSize <= 0 {
+		return -1
+	}
+
+	return config.maxTotalSize - totalSize
+}
+
+func (config *uploadConfig) store(file *UploadedFile, data io.Reader, remainingTotal int64) (int64, *Cause, error) {
+	writer, err := config.sink.Create(file)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var checksum hash.Hash
+	output := io.Writer(writer)
+	if config.checksum != 0 {
+		checksum = config.checksum.New()
+		output = io.MultiWriter(writer, checksum)
+	}
+
+	limit := config.maxFileSize
+	if limit <= 0 || (remainingTotal >= 0 && remainingTotal < limit) {
+		limit = remainingTotal
+	}
+
+	if limit >= 0 {
+		data = &limitedBodyReader{reader: data, remaining: limit}
+	}
+
+	size, err := io.Copy(output, data)
+	closeErr := writer.Close()
+
+	if errors.Is(err, errBodyTooLarge) {
+		if config.maxFileSize > 0 && size >= config.maxFileSize {
+			return size, &Cause{Field: file.FieldName, Code: "tooLarge", Message: "File is too large"}, nil
+		}
+
+		return size, &Cause{Field: "body", Code: "tooLarge", Message: "Request body is too large"}, nil
+	}
+	if err != nil {
+		return size, nil, err
+	}
+	if closeErr != nil {
+		return size, nil, closeErr
+	}
+
+	file.Size = size
+	if checksum != nil {
+		file.Checksum = hex.EncodeToString(checksum.Sum(nil))
+	}
+
+	return size, nil, nil
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"github.com/gin-gonic/gin"
+	ut "github.com/go-playground/universal-translator"
 	"net/http"
 	"reflect"
 	"strings"
@@ -14,22 +15,24 @@ var DefaultValidator *validator.Validate = NewJSONValidator()
 func NewJSONValidator() *validator.Validate {
 	v := validator.New()
 
-	v.RegisterTagNameFunc(func(field reflect.StructField) string {
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" {
-			return field.Name
-		}
+	v.RegisterTagNameFunc(jsonFieldName)
 
-		name := strings.SplitN(jsonTag, ",", 2)[0]
+	return v
+}
 
-		if name == "-" {
-			name = ""
-		}
+func jsonFieldName(field reflect.StructField) string {
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "" {
+		return field.Name
+	}
 
-		return name
-	})
+	name := strings.SplitN(jsonTag, ",", 2)[0]
 
-	return v
+	if name == "-" {
+		name = ""
+	}
+
+	return name
 }
 
 func ValidateStruct(s interface{}) (bool, []Cause) {
@@ -41,13 +44,25 @@ func ValidateVar(name string, value interface{}, tag string) (bool, []Cause) {
 }
 
 func ValidateStructWith(v *validator.Validate, s interface{}) (bool, []Cause) {
+	return validateStruct(v, defaultTranslator(v), s)
+}
+
+func ValidateVarWith(v *validator.Validate, name string, value interface{}, tag string) (bool, []Cause) {
+	return validateVar(v, defaultTranslator(v), name, value, tag)
+}
+
+func ValidationError(c *gin.Context, causes ...Cause) {
+	ErrorResponse(c, http.StatusBadRequest, "Validation Error", causes...)
+}
+
+func validateStruct(v *validator.Validate, trans ut.Translator, s interface{}) (bool, []Cause) {
 	if err := v.Struct(s); err != nil {
 		validationErrors := err.(validator.ValidationErrors)
 		var causes []Cause
 
 		for _, fieldError := range validationErrors {
 			fieldName := extractFieldName(fieldError)
-			causes = append(causes, FieldError(fieldName, fieldError.Tag()))
+			causes = append(causes, FieldErrorMessage(fieldName, fieldError.Tag(), translateFieldError(fieldError, trans)))
 		}
 
 		return false, causes
@@ -56,13 +71,13 @@ func ValidateStructWith(v *validator.Validate, s interface{}) (bool, []Cause) {
 	return true, nil
 }
 
-func ValidateVarWith(v *validator.Validate, name string, value interface{}, tag string) (bool, []Cause) {
+func validateVar(v *validator.Validate, trans ut.Translator, name string, value interface{}, tag string) (bool, []Cause) {
 	if err := v.Var(value, tag); err != nil {
 		validationErrors := err.(validator.ValidationErrors)
 		var causes []Cause
 
 		for _, fieldError := range validationErrors {
-			causes = append(causes, FieldError(name, fieldError.Tag()))
+			causes = append(causes, FieldErrorMessage(name, fieldError.Tag(), translateFieldError(fieldError, trans)))
 		}
 
 		return false, causes
@@ -71,8 +86,17 @@ func ValidateVarWith(v *validator.Validate, name string, value interface{}, tag
 	return true, nil
 }
 
-func ValidationError(c *gin.Context, causes ...Cause) {
-	ErrorResponse(c, http.StatusBadRequest, "Validation Error", causes...)
+func translateFieldError(fieldError validator.FieldError, trans ut.Translator) string {
+	if trans == nil {
+		return ""
+	}
+
+	message := fieldError.Translate(trans)
+	if message == fieldError.Error() { // no translation registered for the tag
+		return ""
+	}
+
+	return message
 }
 
 func extractFieldName(fieldError validator.FieldError) string {